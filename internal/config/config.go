@@ -6,7 +6,11 @@ package config
 
 import (
 	"log"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -17,12 +21,73 @@ import (
 // - Port: HTTP server port (default: 8080)
 // - DatabaseURL: PostgreSQL connection string (required)
 // - JWTSecret: Secret key for JWT token signing/validation (required)
+// - AccessTokenTTL: Lifetime of access tokens (JWTs) before they must be refreshed (default: 15m)
+// - RefreshTokenTTL: How long a session stays valid without calling POST /auth/refresh (default: 30 days)
 // - FrontendURL: Frontend application URL for CORS (default: http://localhost:5173)
+// - JobExpirySweepInterval: How often the expiry sweeper runs (default: 1h)
+// - JobRenewalRequiresPayment: Whether renewing a job needs a fresh payment_tx_hash (default: true)
+// - AlertNotifier: How saved-search alerts are delivered besides the in-app inbox: "log", "smtp" or "none" (default: log)
+// - SMTPAddr / SMTPUsername / SMTPPassword / SMTPFrom: SMTP server settings used when AlertNotifier or Mailer is "smtp"
+// - Mailer: How account emails (verification and reset links) are sent: "smtp", "log" (development only) or "none" (default: none)
+// - EmailVerifyURL: Frontend page verification links point to; it posts the token to POST /auth/verify-email (default: FrontendURL + "/verify-email")
+// - EmailVerificationTTL: How long a verification link stays valid (default: 24h)
+// - PasswordResetURL: Frontend page password reset links point to; it posts the token to POST /auth/reset-password (default: FrontendURL + "/reset-password")
+// - PasswordResetTTL: How long a password reset link stays valid (default: 60m)
+// - TOTPIssuer: Service name authenticator apps show for two-factor codes (default: Job Portal)
+// - OIDCProviders: Social login providers from OIDC_PROVIDERS (e.g., "google,linkedin"), each configured by OIDC_<NAME>_* variables
+// - OIDCRedirectURL: Frontend page providers redirect back to; it posts code and state to POST /auth/oidc/callback (default: FrontendURL + "/auth/callback")
+// - EthRPCURL: Ethereum JSON-RPC endpoint for on-chain payment verification (empty disables it)
+// - AdminWallet: Platform wallet that job payments must be sent to (required with EthRPCURL)
+// - ChainID: EVM chain ID job payments are made on, used as the payments ledger key (default: 11155111, Sepolia)
+// - ERC20TokenAddress: ERC-20 token accepted with payment_method "erc20" (needs EthRPCURL; empty disables it)
+// - PaymentFake: Enables the in-memory "fake" payment method that accepts any reference (development only, default: false)
+// - PaymentConfirmations: Confirmations a job payment needs before the job goes live (default: 3)
+// - PaymentConfirmationTimeout: How long a job may wait in pending_payment before it fails (default: 60m)
+// - PaymentPollInterval: How often the confirmation worker checks pending payments (default: 15s)
+// - SIWEDomain: Domain Sign-In with Ethereum messages must be issued for (default: host of FrontendURL)
 type Config struct {
-	Port        string
-	DatabaseURL string
-	JWTSecret   string
-	FrontendURL string
+	Port                       string
+	DatabaseURL                string
+	JWTSecret                  string
+	AccessTokenTTL             time.Duration
+	RefreshTokenTTL            time.Duration
+	FrontendURL                string
+	JobExpirySweepInterval     time.Duration
+	JobRenewalRequiresPayment  bool
+	AlertNotifier              string
+	SMTPAddr                   string
+	SMTPUsername               string
+	SMTPPassword               string
+	SMTPFrom                   string
+	Mailer                     string
+	EmailVerifyURL             string
+	EmailVerificationTTL       time.Duration
+	PasswordResetURL           string
+	PasswordResetTTL           time.Duration
+	TOTPIssuer                 string
+	OIDCProviders              []OIDCProviderConfig
+	OIDCRedirectURL            string
+	EthRPCURL                  string
+	AdminWallet                string
+	ChainID                    int64
+	ERC20TokenAddress          string
+	PaymentFake                bool
+	PaymentConfirmations       int
+	PaymentConfirmationTimeout time.Duration
+	PaymentPollInterval        time.Duration
+	SIWEDomain                 string
+}
+
+// OIDCProviderConfig holds the OIDC_<NAME>_* settings of one social login provider.
+// URLs left empty fall back to the provider's preset (google, linkedin); other
+// providers, such as a local mock server, need all three.
+type OIDCProviderConfig struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
 }
 
 func LoadConfig() *Config {
@@ -49,10 +114,134 @@ func LoadConfig() *Config {
 		frontendURL = "http://localhost:5173"
 	}
 
+	alertNotifier := os.Getenv("ALERT_NOTIFIER")
+	if alertNotifier == "" {
+		alertNotifier = "log"
+	}
+
+	smtpAddr := os.Getenv("SMTP_ADDR")
+	if smtpAddr == "" {
+		smtpAddr = "localhost:1025"
+	}
+
+	smtpFrom := os.Getenv("SMTP_FROM")
+	if smtpFrom == "" {
+		smtpFrom = "alerts@localhost"
+	}
+
+	mailer := os.Getenv("MAILER")
+	if mailer == "" {
+		mailer = "none"
+	}
+
+	emailVerifyURL := os.Getenv("EMAIL_VERIFY_URL")
+	if emailVerifyURL == "" {
+		emailVerifyURL = frontendURL + "/verify-email"
+	}
+
+	passwordResetURL := os.Getenv("PASSWORD_RESET_URL")
+	if passwordResetURL == "" {
+		passwordResetURL = frontendURL + "/reset-password"
+	}
+
+	totpIssuer := os.Getenv("TOTP_ISSUER")
+	if totpIssuer == "" {
+		totpIssuer = "Job Portal"
+	}
+
+	var oidcProviders []OIDCProviderConfig
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		oidcProviders = append(oidcProviders, OIDCProviderConfig{
+			Name:         name,
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			AuthURL:      os.Getenv(prefix + "AUTH_URL"),
+			TokenURL:     os.Getenv(prefix + "TOKEN_URL"),
+			UserInfoURL:  os.Getenv(prefix + "USERINFO_URL"),
+		})
+	}
+
+	oidcRedirectURL := os.Getenv("OIDC_REDIRECT_URL")
+	if oidcRedirectURL == "" {
+		oidcRedirectURL = frontendURL + "/auth/callback"
+	}
+
+	ethRPCURL := os.Getenv("ETH_RPC_URL")
+	adminWallet := os.Getenv("ADMIN_WALLET")
+	if ethRPCURL != "" && adminWallet == "" {
+		log.Fatal("ADMIN_WALLET is required when ETH_RPC_URL is set")
+	}
+
+	siweDomain := os.Getenv("SIWE_DOMAIN")
+	if siweDomain == "" {
+		if u, err := url.Parse(frontendURL); err == nil && u.Host != "" {
+			siweDomain = u.Host
+		}
+	}
+
 	return &Config{
-		Port:        port,
-		DatabaseURL: dbURL,
-		JWTSecret:   jwt,
-		FrontendURL: frontendURL,
+		Port:                       port,
+		DatabaseURL:                dbURL,
+		JWTSecret:                  jwt,
+		AccessTokenTTL:             time.Duration(getEnvInt("ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute,
+		RefreshTokenTTL:            time.Duration(getEnvInt("REFRESH_TOKEN_TTL_DAYS", 30)) * 24 * time.Hour,
+		FrontendURL:                frontendURL,
+		JobExpirySweepInterval:     time.Duration(getEnvInt("JOB_EXPIRY_SWEEP_MINUTES", 60)) * time.Minute,
+		JobRenewalRequiresPayment:  getEnvBool("JOB_RENEWAL_REQUIRES_PAYMENT", true),
+		AlertNotifier:              alertNotifier,
+		SMTPAddr:                   smtpAddr,
+		SMTPUsername:               os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:               os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:                   smtpFrom,
+		Mailer:                     mailer,
+		EmailVerifyURL:             emailVerifyURL,
+		EmailVerificationTTL:       time.Duration(getEnvInt("EMAIL_VERIFICATION_TTL_HOURS", 24)) * time.Hour,
+		PasswordResetURL:           passwordResetURL,
+		PasswordResetTTL:           time.Duration(getEnvInt("PASSWORD_RESET_TTL_MINUTES", 60)) * time.Minute,
+		TOTPIssuer:                 totpIssuer,
+		OIDCProviders:              oidcProviders,
+		OIDCRedirectURL:            oidcRedirectURL,
+		EthRPCURL:                  ethRPCURL,
+		AdminWallet:                adminWallet,
+		ChainID:                    int64(getEnvInt("ETH_CHAIN_ID", 11155111)),
+		ERC20TokenAddress:          os.Getenv("ERC20_TOKEN_ADDRESS"),
+		PaymentFake:                getEnvBool("PAYMENT_FAKE", false),
+		PaymentConfirmations:       getEnvInt("PAYMENT_CONFIRMATIONS", 3),
+		PaymentConfirmationTimeout: time.Duration(getEnvInt("PAYMENT_CONFIRMATION_TIMEOUT_MINUTES", 60)) * time.Minute,
+		PaymentPollInterval:        time.Duration(getEnvInt("PAYMENT_POLL_SECONDS", 15)) * time.Second,
+		SIWEDomain:                 siweDomain,
+	}
+}
+
+// getEnvInt reads a positive integer env var, falling back to def if unset or invalid.
+func getEnvInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		log.Printf("invalid %s=%q, using default %d", key, v, def)
+		return def
+	}
+	return n
+}
+
+// getEnvBool reads a boolean env var (true/false/1/0), falling back to def if unset or invalid.
+func getEnvBool(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Printf("invalid %s=%q, using default %t", key, v, def)
+		return def
 	}
+	return b
 }
@@ -0,0 +1,131 @@
+// Application handler contains endpoints for applying to jobs and reviewing applications.
+package handlers
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+)
+
+// applyJobRequest represents the JSON payload for applying to a job.
+type applyJobRequest struct {
+	CoverNote string `json:"cover_note,omitempty"`
+	ResumeURL string `json:"resume_url,omitempty"`
+}
+
+// ApplyToJob handles a candidate applying to a job (POST /jobs/:id/apply).
+// The AI match score at the time of applying is stored with the application.
+//
+// Requires: Authorization: Bearer <token>
+// Request body (all fields optional):
+//
+//	{
+//	  "cover_note": "I'd love to join your team...",
+//	  "resume_url": "https://example.com/resume.pdf"
+//	}
+//
+// Response on success (201 Created): the created application
+//
+// Error responses:
+// - 400: Invalid request, applying to own job, or job closed
+// - 404: Job not found
+// - 409: Already applied to this job
+// - 500: Database error
+func ApplyToJob(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	var req applyJobRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+		}
+	}
+
+	app, err := services.ApplyToJob(c.Context(), id, uidStr, req.CoverNote, req.ResumeURL)
+	if err != nil {
+		switch err {
+		case services.ErrJobNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "job not found"})
+		case services.ErrAlreadyApplied:
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		case services.ErrOwnJob, services.ErrJobClosed:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to apply to job"})
+	}
+
+	if err := services.RecordJobEvent(app.JobID.String(), uidStr, services.JobEventApply); err != nil {
+		log.Println("record job application failed:", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(app)
+}
+
+// MyApplications handles listing the current user's applications (GET /me/applications).
+//
+// Requires: Authorization: Bearer <token>
+// Returns: Array of applications with job titles, newest first
+func MyApplications(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	apps, err := services.ListUserApplications(uidStr)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch applications"})
+	}
+
+	if apps == nil {
+		apps = []models.Application{}
+	}
+
+	return c.JSON(apps)
+}
+
+// ListJobApplications handles listing applications for a job (GET /jobs/:id/applications).
+// Only the poster and members of the job's company can view its applications.
+//
+// Requires: Authorization: Bearer <token>
+// Returns: Array of applications with applicant name/email, best match first
+//
+// Error responses:
+// - 403: Caller is neither the poster nor a member of the job's company
+// - 404: Job not found
+// - 500: Database error
+func ListJobApplications(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	apps, err := services.ListJobApplications(id, uidStr)
+	if err != nil {
+		return jobOwnerError(c, err, "failed to fetch applications")
+	}
+
+	if apps == nil {
+		apps = []models.Application{}
+	}
+
+	return c.JSON(apps)
+}
@@ -5,6 +5,8 @@
 package handlers
 
 import (
+	"log"
+
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/Akshatt02/job-portal-backend/internal/config"
@@ -19,6 +21,11 @@ type registerRequest struct {
 	Password string `json:"password"`
 }
 
+// refreshRequest represents the JSON payload for renewing an access token.
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 // loginRequest represents the JSON payload for user login.
 type loginRequest struct {
 	Email    string `json:"email"`
@@ -35,8 +42,14 @@ type loginRequest struct {
 //	  "password": "secure_password"
 //	}
 //
+// New accounts are candidates. Posting jobs needs the recruiter role, granted by an admin
+// or by joining a company as owner or recruiter.
+//
 // Response on success (201 Created):
-// { "token": "eyJhbGc..." }
+// { "token": "eyJhbGc...", "refresh_token": "...", "expires_in": 900, "email_verified": false }
+//
+// A verification link is emailed to the address (EMAIL_VERIFY_URL?token=...).
+// Until it is used, POST /jobs and POST /posts return 403.
 //
 // Error responses:
 // - 400: Invalid request, missing fields, or email already exists
@@ -55,13 +68,20 @@ func Register(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	// The account works right away, but posting waits for the emailed link.
+	// A failed send isn't fatal: the user can ask for a new link.
 	cfg := config.LoadConfig()
-	token, err := utils.GenerateJWT(id, cfg.JWTSecret)
+	if err := services.SendVerificationEmail(id, cfg.JWTSecret, cfg.EmailVerifyURL, cfg.EmailVerificationTTL); err != nil {
+		log.Printf("verification email for user %s: %v", id, err)
+	}
+
+	tokens, err := newSession(c, id)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create token"})
 	}
+	tokens["email_verified"] = false
 
-	return c.JSON(fiber.Map{"token": token})
+	return c.JSON(tokens)
 }
 
 // Login handles user authentication (POST /auth/login).
@@ -74,7 +94,15 @@ func Register(c *fiber.Ctx) error {
 //	}
 //
 // Response on success (200 OK):
-// { "token": "eyJhbGc..." }
+// { "token": "eyJhbGc...", "refresh_token": "...", "expires_in": 900 }
+//
+// - token: Short-lived access token (ACCESS_TOKEN_TTL_MINUTES), sent as Authorization: Bearer <token>
+// - refresh_token: Opaque token for POST /auth/refresh; store it securely, it is shown only once
+// - expires_in: Access token lifetime in seconds
+//
+// With two-factor authentication enabled, the password only completes the first step:
+// { "mfa_required": true, "mfa_token": "eyJhbGc...", "mfa_expires_in": 300 }
+// Exchange mfa_token and a code at POST /auth/2fa/verify for the tokens above.
 //
 // Error responses:
 // - 400: Missing email or password
@@ -94,11 +122,152 @@ func Login(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid credentials"})
 	}
 
+	tokens, err := signIn(c, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create token"})
+	}
+
+	return c.JSON(tokens)
+}
+
+// Refresh handles renewing an access token (POST /auth/refresh).
+// No Authorization header needed; the refresh token identifies the session.
+//
+// The new access token carries the user's current roles, so refresh after a role grant.
+//
+// Refresh tokens rotate: each one works once and the response carries its replacement.
+// Presenting an already used refresh token revokes the whole session, because it means
+// the token was copied; every client of that session has to sign in again.
+//
+// Request body:
+//
+//	{ "refresh_token": "..." }
+//
+// Response on success (200 OK):
+// { "token": "eyJhbGc...", "refresh_token": "...", "expires_in": 900 }
+//
+// Error responses:
+// - 400: Missing refresh_token
+// - 401: Unknown, expired, revoked or reused refresh token
+// - 500: Internal server error
+func Refresh(c *fiber.Ctx) error {
+	var req refreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request"})
+	}
+	if req.RefreshToken == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "refresh_token required"})
+	}
+
 	cfg := config.LoadConfig()
-	token, err := utils.GenerateJWT(id, cfg.JWTSecret)
+	userID, sessionID, refreshToken, err := services.RotateRefreshToken(req.RefreshToken, cfg.RefreshTokenTTL)
+	if err != nil {
+		if err == services.ErrInvalidRefreshToken || err == services.ErrRefreshTokenReused {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to refresh token"})
+	}
+
+	token, err := accessToken(userID, sessionID, cfg)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create token"})
 	}
 
-	return c.JSON(fiber.Map{"token": token})
+	return c.JSON(tokenResponse(token, refreshToken, cfg))
+}
+
+// Logout handles signing out the current session (POST /auth/logout).
+// The access token used for this request and the session's refresh token stop working.
+//
+// Requires: Authorization: Bearer <token>
+//
+// Response on success (200 OK):
+// { "message": "logged out" }
+func Logout(c *fiber.Ctx) error {
+	userID, _ := c.Locals("user_id").(string)
+	sessionID, ok := c.Locals("session_id").(string)
+	if userID == "" || !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+
+	if err := services.RevokeSession(userID, sessionID); err != nil && err != services.ErrSessionNotFound {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to log out"})
+	}
+	return c.JSON(fiber.Map{"message": "logged out"})
+}
+
+// LogoutAll handles signing out every session of the user, including this one (POST /auth/logout-all).
+// Use it after a lost device or a leaked password.
+//
+// Requires: Authorization: Bearer <token>
+//
+// Response on success (200 OK):
+// { "message": "logged out everywhere", "sessions_revoked": 3 }
+func LogoutAll(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+
+	n, err := services.RevokeAllSessions(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to log out"})
+	}
+	return c.JSON(fiber.Map{"message": "logged out everywhere", "sessions_revoked": n})
+}
+
+// signIn finishes the first login factor: users without two-factor authentication
+// get a session right away, the others an mfa_token for POST /auth/2fa/verify.
+func signIn(c *fiber.Ctx, userID string) (fiber.Map, error) {
+	enabled, err := services.IsTOTPEnabled(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return newSession(c, userID)
+	}
+
+	cfg := config.LoadConfig()
+	mfaToken, err := utils.GenerateMFAChallengeToken(userID, cfg.JWTSecret, services.MFAChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+	return fiber.Map{
+		"mfa_required":   true,
+		"mfa_token":      mfaToken,
+		"mfa_expires_in": int(services.MFAChallengeTTL.Seconds()),
+	}, nil
+}
+
+// newSession starts a session for a user who just signed in and returns the
+// token response (access token, refresh token and expires_in).
+func newSession(c *fiber.Ctx, userID string) (fiber.Map, error) {
+	cfg := config.LoadConfig()
+	sessionID, refreshToken, err := services.CreateSession(userID, c.Get(fiber.HeaderUserAgent), c.IP(), cfg.RefreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	token, err := accessToken(userID, sessionID, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return tokenResponse(token, refreshToken, cfg), nil
+}
+
+// accessToken signs an access token for the session carrying the user's current roles.
+func accessToken(userID, sessionID string, cfg *config.Config) (string, error) {
+	roles, err := services.GetRoles(userID)
+	if err != nil {
+		return "", err
+	}
+	return utils.GenerateJWT(userID, sessionID, roles, cfg.JWTSecret, cfg.AccessTokenTTL)
+}
+
+// tokenResponse builds the JSON body returned by login, registration and refresh.
+func tokenResponse(token, refreshToken string, cfg *config.Config) fiber.Map {
+	return fiber.Map{
+		"token":         token,
+		"refresh_token": refreshToken,
+		"expires_in":    int(cfg.AccessTokenTTL.Seconds()),
+	}
 }
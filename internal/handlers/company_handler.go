@@ -0,0 +1,317 @@
+// Company handler contains endpoints for company profiles, memberships and company jobs.
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
+)
+
+// companyRequest represents the JSON payload for creating a company.
+type companyRequest struct {
+	Name        string `json:"name"`
+	LogoURL     string `json:"logo_url,omitempty"`
+	Website     string `json:"website,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// updateCompanyRequest represents the JSON payload for company updates.
+// All fields are optional; only provided fields are modified.
+type updateCompanyRequest struct {
+	Name        *string `json:"name,omitempty"`
+	LogoURL     *string `json:"logo_url,omitempty"`
+	Website     *string `json:"website,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// companyMemberRequest represents the JSON payload for adding a member or changing a role.
+type companyMemberRequest struct {
+	Email string `json:"email,omitempty"`
+	Role  string `json:"role"`
+}
+
+// companyError maps company service errors to HTTP responses.
+func companyError(c *fiber.Ctx, err error, fallback string) error {
+	switch err {
+	case services.ErrCompanyNotFound, services.ErrMemberNotFound, services.ErrMemberUserNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrNotCompanyMember, services.ErrCompanyRole:
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrInvalidCompany, services.ErrInvalidCompanyRole:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrMemberExists, services.ErrLastCompanyOwner:
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fallback})
+}
+
+// CreateCompany handles company creation (POST /companies).
+// The caller becomes the company's first owner.
+//
+// Requires: Authorization: Bearer <token>
+// Request body:
+//
+//	{
+//	  "name": "Acme Corp",
+//	  "logo_url": "https://acme.example/logo.png",
+//	  "website": "https://acme.example",
+//	  "description": "We build rockets."
+//	}
+//
+// Response on success (201 Created): the company with "role": "owner"
+func CreateCompany(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	var req companyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	company, err := services.CreateCompany(uidStr, models.Company{
+		Name:        req.Name,
+		LogoURL:     req.LogoURL,
+		Website:     req.Website,
+		Description: req.Description,
+	})
+	if err != nil {
+		return companyError(c, err, "failed to create company")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(company)
+}
+
+// GetCompany handles public company profile retrieval (GET /companies/:id).
+//
+// Returns: the company profile
+//
+// Error responses:
+// - 404: Company not found
+func GetCompany(c *fiber.Ctx) error {
+	company, err := services.GetCompany(c.Params("id"))
+	if err != nil {
+		return companyError(c, err, "failed to fetch company")
+	}
+	return c.JSON(company)
+}
+
+// UpdateCompany handles company profile edits (PUT /companies/:id).
+// Supports partial updates - only provided fields are modified.
+//
+// Requires: Authorization: Bearer <token> (company owner)
+// Returns: the updated company profile
+//
+// Error responses:
+// - 400: Empty name
+// - 403: Caller is not an owner of the company
+// - 404: Company not found
+func UpdateCompany(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	var req updateCompanyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	updates := map[string]interface{}{}
+	for field, v := range map[string]*string{
+		"name":        req.Name,
+		"logo_url":    req.LogoURL,
+		"website":     req.Website,
+		"description": req.Description,
+	} {
+		if v != nil {
+			updates[field] = *v
+		}
+	}
+
+	company, err := services.UpdateCompany(c.Params("id"), uidStr, updates)
+	if err != nil {
+		return companyError(c, err, "failed to update company")
+	}
+	return c.JSON(company)
+}
+
+// MyCompanies handles listing the companies the current user belongs to (GET /me/companies).
+//
+// Requires: Authorization: Bearer <token>
+// Returns: Array of companies with the caller's role in each
+func MyCompanies(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	companies, err := services.ListUserCompanies(uidStr)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch companies"})
+	}
+
+	if companies == nil {
+		companies = []models.Company{}
+	}
+
+	return c.JSON(companies)
+}
+
+// ListCompanyMembers handles listing a company's members (GET /companies/:id/members).
+//
+// Requires: Authorization: Bearer <token> (any company member)
+// Returns: Array of members with name, email and role, owners first
+func ListCompanyMembers(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	members, err := services.ListCompanyMembers(c.Params("id"), uidStr)
+	if err != nil {
+		return companyError(c, err, "failed to fetch company members")
+	}
+
+	if members == nil {
+		members = []models.CompanyMember{}
+	}
+
+	return c.JSON(members)
+}
+
+// AddCompanyMember handles adding a registered user to a company (POST /companies/:id/members).
+//
+// Requires: Authorization: Bearer <token> (company owner)
+// Request body:
+//
+//	{ "email": "recruiter@acme.example", "role": "recruiter" }
+//
+// Roles: owner, recruiter (manages company jobs and applications), viewer (read-only)
+//
+// Response on success (201 Created): the new membership
+//
+// Error responses:
+// - 400: Invalid role
+// - 403: Caller is not an owner of the company
+// - 404: Company or user not found
+// - 409: User is already a member
+func AddCompanyMember(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	var req companyMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+	if req.Email == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "email required"})
+	}
+
+	member, err := services.AddCompanyMember(c.Params("id"), uidStr, req.Email, req.Role)
+	if err != nil {
+		return companyError(c, err, "failed to add company member")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(member)
+}
+
+// UpdateCompanyMember handles changing a member's role (PUT /companies/:id/members/:user_id).
+//
+// Requires: Authorization: Bearer <token> (company owner)
+// Request body: { "role": "viewer" }
+// Response on success: 204 No Content
+//
+// Error responses:
+// - 400: Invalid role
+// - 403: Caller is not an owner of the company
+// - 404: Company or member not found
+// - 409: Would leave the company without an owner
+func UpdateCompanyMember(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	var req companyMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	if err := services.UpdateCompanyMemberRole(c.Params("id"), uidStr, c.Params("user_id"), req.Role); err != nil {
+		return companyError(c, err, "failed to update company member")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// RemoveCompanyMember handles removing a member from a company (DELETE /companies/:id/members/:user_id).
+// Owners can remove anyone; members can remove themselves to leave the company.
+//
+// Requires: Authorization: Bearer <token>
+// Response on success: 204 No Content
+//
+// Error responses:
+// - 403: Caller may not remove this member
+// - 404: Company or member not found
+// - 409: Would leave the company without an owner
+func RemoveCompanyMember(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	if err := services.RemoveCompanyMember(c.Params("id"), uidStr, c.Params("user_id")); err != nil {
+		return companyError(c, err, "failed to remove company member")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListCompanyJobs handles listing a company's jobs in any status (GET /companies/:id/jobs).
+//
+// Requires: Authorization: Bearer <token> (any company member)
+// Query Parameters:
+// - ?status=draft - Only jobs in this status (default: all)
+// - ?limit=20, ?cursor=... - Pagination as in GET /jobs
+//
+// Returns: { items: [...], next_cursor }, newest first
+func ListCompanyJobs(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	status := c.Query("status")
+	if status == "all" {
+		status = ""
+	}
+	if status != "" && !services.IsValidJobStatus(status) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid status"})
+	}
+
+	jobs, next, err := services.ListCompanyJobs(c.Params("id"), uidStr, status, pageLimit(c), c.Query("cursor"))
+	if err != nil {
+		if err == utils.ErrInvalidCursor {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid cursor"})
+		}
+		return companyError(c, err, "failed to list company jobs")
+	}
+	if jobs == nil {
+		jobs = []*models.Job{}
+	}
+	return c.JSON(pageResponse(jobs, next))
+}
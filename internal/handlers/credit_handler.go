@@ -0,0 +1,127 @@
+// Credit handler contains the prepaid posting credit endpoints.
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/config"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+)
+
+// purchaseCreditsRequest represents the JSON payload for buying posting credits.
+type purchaseCreditsRequest struct {
+	Plan          string `json:"plan,omitempty"`
+	Quantity      int    `json:"quantity"`
+	PaymentTxHash string `json:"payment_tx_hash"`
+	PaymentMethod string `json:"payment_method,omitempty"`
+	CompanyID     string `json:"company_id,omitempty"`
+}
+
+// MyCredits handles the posting credit account view (GET /me/credits).
+//
+// Requires: Authorization: Bearer <token>
+// Query: ?company_id= shows a company's credits instead of your own (any member)
+//
+// Returns:
+//
+//	{
+//	  "balances": [ { "plan": "basic", "credits": 9 } ],
+//	  "history": [ { "id": "...", "plan": "basic", "delta": -1, "reason": "job_post", "job_id": "...", "actor_id": "...", "created_at": "..." }, ... ]
+//	}
+//
+// Error responses:
+// - 403: Not a member of the company
+// - 404: Company not found
+func MyCredits(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+
+	credits, err := services.GetCredits(userID.(string), c.Query("company_id"))
+	if err != nil {
+		return creditError(c, err, "failed to fetch credits")
+	}
+	return c.JSON(credits)
+}
+
+// PurchaseCredits handles buying posting credits in bulk with one payment (POST /me/credits).
+// Each credit pays for one POST /jobs on the plan without a payment_tx_hash.
+//
+// Requires: Authorization: Bearer <token>
+// Request body:
+//
+//	{
+//	  "plan": "basic",
+//	  "quantity": 10,
+//	  "payment_tx_hash": "0x...(66 chars)",
+//	  "payment_method": "evm_native",
+//	  "company_id": "company-uuid"
+//	}
+//
+// - plan defaults to "basic"; quantity is 1-100
+// - The payment must cover quantity × the plan's price for the payment method
+// - It must be fully confirmed (PAYMENT_CONFIRMATIONS); otherwise retry with the same hash later
+// - company_id buys credits for a company (owner or recruiter)
+//
+// Response on success (201 Created): the account, as GET /me/credits
+//
+// Error responses:
+// - 400: Invalid quantity, plan, payment method or payment (including not yet confirmed)
+// - 403: Company role doesn't allow buying
+// - 404: Plan or company not found
+// - 409: payment_tx_hash already used
+// - 502: Payment provider unreachable
+func PurchaseCredits(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+
+	var req purchaseCreditsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	cfg := config.LoadConfig()
+	credits, err := services.PurchaseCredits(services.PurchaseCreditsInput{
+		UserID:        userID.(string),
+		CompanyID:     req.CompanyID,
+		Plan:          req.Plan,
+		Quantity:      req.Quantity,
+		PaymentTxHash: req.PaymentTxHash,
+		PaymentMethod: req.PaymentMethod,
+		ChainID:       cfg.ChainID,
+		Confirmations: cfg.PaymentConfirmations,
+	})
+	if err != nil {
+		return creditError(c, err, "failed to buy credits")
+	}
+	return c.Status(fiber.StatusCreated).JSON(credits)
+}
+
+// creditError maps posting credit service errors to HTTP responses.
+func creditError(c *fiber.Ctx, err error, fallback string) error {
+	switch err {
+	case services.ErrCompanyNotFound, services.ErrPlanNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrNotCompanyMember, services.ErrCompanyRole:
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrInvalidCreditQuantity, services.ErrInvalidTxHash, services.ErrPaymentRequired,
+		services.ErrPaymentNotFound, services.ErrPaymentPending, services.ErrWalletRequired:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, services.ErrTxHashReused) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, services.ErrInvalidPlan) || errors.Is(err, services.ErrPaymentRejected) ||
+		errors.Is(err, services.ErrUnknownPaymentMethod) || errors.Is(err, services.ErrPlanMethodUnavailable) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, services.ErrPaymentUnavailable) {
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": services.ErrPaymentUnavailable.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fallback})
+}
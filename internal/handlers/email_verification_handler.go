@@ -0,0 +1,81 @@
+// Email verification handler contains the endpoints that confirm a user's email address.
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/config"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
+)
+
+// verifyEmailRequest represents the JSON payload for confirming an email address.
+type verifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// VerifyEmail handles confirming an email address (POST /auth/verify-email).
+// No authentication required; the signed token from the emailed link identifies the user.
+//
+// Request body:
+//
+//	{ "token": "eyJhbGc..." }
+//
+// Response on success (200 OK):
+// { "message": "email verified", "email_verified": true }
+//
+// Error responses:
+// - 400: Missing, invalid or expired token (request a new one with POST /auth/verify-email/resend)
+// - 500: Internal server error
+func VerifyEmail(c *fiber.Ctx) error {
+	var req verifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request"})
+	}
+	if req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "token required"})
+	}
+
+	cfg := config.LoadConfig()
+	if err := services.VerifyEmail(req.Token, cfg.JWTSecret); err != nil {
+		if errors.Is(err, utils.ErrInvalidEmailToken) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to verify email"})
+	}
+
+	return c.JSON(fiber.Map{"message": "email verified", "email_verified": true})
+}
+
+// ResendVerificationEmail handles sending a new verification link (POST /auth/verify-email/resend).
+// Earlier links keep working until they expire.
+//
+// Requires: Authorization: Bearer <token>
+//
+// Response on success (200 OK):
+// { "message": "verification email sent" }
+//
+// Error responses:
+// - 409: Email already verified
+// - 429: A link was sent less than a minute ago
+// - 503: Email delivery not configured or failed
+func ResendVerificationEmail(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+
+	cfg := config.LoadConfig()
+	err := services.SendVerificationEmail(userID, cfg.JWTSecret, cfg.EmailVerifyURL, cfg.EmailVerificationTTL)
+	switch err {
+	case nil:
+		return c.JSON(fiber.Map{"message": "verification email sent"})
+	case services.ErrEmailAlreadyVerified:
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrVerificationEmailTooSoon:
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "failed to send verification email"})
+}
@@ -1,26 +1,73 @@
 package handlers
 
 import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/Akshatt02/job-portal-backend/internal/config"
 	"github.com/Akshatt02/job-portal-backend/internal/models"
 	"github.com/Akshatt02/job-portal-backend/internal/services"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
 )
 
 // createJobRequest represents the JSON payload for creating a new job posting.
 type createJobRequest struct {
-	Title         string   `json:"title"`
-	Description   string   `json:"description"`
-	Skills        []string `json:"skills,omitempty"`
-	Salary        string   `json:"salary,omitempty"`
-	Location      string   `json:"location,omitempty"`
-	PaymentTxHash string   `json:"payment_tx_hash,omitempty"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description"`
+	Skills        []string       `json:"skills,omitempty"`
+	Salary        *models.Salary `json:"salary,omitempty"`
+	Location      string         `json:"location,omitempty"`
+	PaymentTxHash string         `json:"payment_tx_hash,omitempty"`
+	PaymentMethod string         `json:"payment_method,omitempty"`
+	Plan          string         `json:"plan,omitempty"`
+	Status        string         `json:"status,omitempty"`
+	CompanyID     string         `json:"company_id,omitempty"`
+	// Classification (optional, allowed values from GET /jobs/taxonomy)
+	Category       string `json:"category,omitempty"`
+	EmploymentType string `json:"employment_type,omitempty"`
+	Seniority      string `json:"seniority,omitempty"`
+	// Structured location fields: work_arrangement, country, city, latitude, longitude, utc_offset
+	models.Place
+}
+
+// jobStatusRequest represents the JSON payload for changing a job's status.
+type jobStatusRequest struct {
+	Status string `json:"status"`
+}
+
+// renewJobRequest represents the JSON payload for renewing a job listing.
+type renewJobRequest struct {
+	PaymentTxHash string `json:"payment_tx_hash,omitempty"`
+	PaymentMethod string `json:"payment_method,omitempty"`
+}
+
+// updateJobRequest represents the JSON payload for job updates.
+// All fields are optional; only provided fields are modified.
+type updateJobRequest struct {
+	Title       *string        `json:"title,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	Skills      []string       `json:"skills,omitempty"`
+	Salary      *models.Salary `json:"salary,omitempty"`
+	Location    *string        `json:"location,omitempty"`
+	// Classification; "" clears the value
+	Category       *string `json:"category,omitempty"`
+	EmploymentType *string `json:"employment_type,omitempty"`
+	Seniority      *string `json:"seniority,omitempty"`
+	// Structured location; allocated only if any of its fields are present
+	*models.Place
 }
 
-// jobWithScoreResponse represents a job with its AI-computed match score.
+// jobWithScoreResponse represents a job with its AI-computed match score
+// and whether the current user has saved it.
 type jobWithScoreResponse struct {
 	*models.Job `json:"job"`
-	MatchScore  int `json:"match_score"`
+	MatchScore  int                  `json:"match_score"`
+	Saved       bool                 `json:"saved"`
+	Payment     *models.PaymentState `json:"payment,omitempty"`
 }
 
 // CreateJob handles job posting creation (POST /jobs).
@@ -30,19 +77,59 @@ type jobWithScoreResponse struct {
 //	{
 //	  "title": "Senior Go Developer",
 //	  "description": "Looking for experienced Go developer...",
-//	  "location": "Remote",
-//	  "salary": "$120k-150k",
+//	  "location": "Berlin (hybrid)",
+//	  "work_arrangement": "hybrid",
+//	  "city": "Berlin",
+//	  "country": "DE",
+//	  "salary": { "min": 120000, "max": 150000, "currency": "USD", "period": "yearly" },
 //	  "skills": ["go", "postgresql", "docker"],
-//	  "payment_tx_hash": "0x123abc...(66 chars)"
+//	  "category": "engineering",
+//	  "employment_type": "full_time",
+//	  "seniority": "senior",
+//	  "payment_tx_hash": "0x123abc...(66 chars)",
+//	  "payment_method": "evm_native",
+//	  "plan": "featured",
+//	  "status": "published",
+//	  "company_id": "company-uuid"
 //	}
 //
+// Company (optional): post on behalf of a company; the caller must be its owner or recruiter.
+// All members of the company can then see the job and its applications.
+// Without company_id the caller needs the jobs:create permission (recruiter or admin role).
+//
+// Location (optional): work_arrangement is remote, hybrid or onsite. When city is given without
+// latitude/longitude, coordinates and utc_offset are filled in from the offline gazetteer.
+//
+// Salary (optional): min and/or max, 3-letter currency code, period hourly/monthly/yearly (default yearly).
+//
+// Plan (optional): an active pricing plan from GET /plans (default "basic"). The plan sets the
+// price the payment must cover and how many days the job stays listed once published.
+//
+// Status (optional): "draft" (not listed yet) or "published" (default).
+// Published jobs are listed for the plan's duration_days, then expire.
+//
 // Payment Requirements:
-// - payment_tx_hash: Ethereum Sepolia transaction hash (format: 0x + 64 hex chars)
-// - Must be a valid transaction from user to platform wallet (0.001 SETH)
-// - Validates format before accepting job posting
+// - Without payment_tx_hash a prepaid posting credit for the plan is spent (POST /me/credits); 402 if none is left
+// - Company jobs spend the company's credits, other jobs the poster's own
+// - payment_method (optional): "evm_native" (default), "erc20" or "fake", when enabled; 400 otherwise
+// - payment_tx_hash: Ethereum Sepolia transaction hash (format: 0x + 64 hex chars) for EVM methods
+// - evm_native: a mined, successful transfer of at least the plan's price_wei to ADMIN_WALLET
+// - erc20: a mined transaction with Transfer logs of ERC20_TOKEN_ADDRESS to ADMIN_WALLET worth at least the plan's prices["erc20"]
+// - Must be sent from the poster's verified wallet_address (POST /auth/siwe/verify)
+// - Verified on-chain via ETH_RPC_URL; without it evm_native payments are only checked for format
+// - Needs PAYMENT_CONFIRMATIONS confirmations (default 3) before the job goes live
+// - 502 if the payment provider is unreachable
 //
 // Response on success (201 Created):
-// { "id": "job-uuid", "message": "Job posted successfully with blockchain payment confirmation" }
+// { "id": "job-uuid", "status": "published", "message": "Job posted successfully with blockchain payment confirmation" }
+//
+// A transaction hash the node doesn't know yet returns 400; send it again once the transaction is broadcast.
+// A broadcast transaction is checked for recipient, value and sender before it is accepted as pending.
+//
+// Response when the transaction is not confirmed yet (202 Accepted):
+// { "id": "job-uuid", "status": "pending_payment", "message": "..." }
+// The job is published (or kept as a draft) once the payment is confirmed, or moves to
+// payment_failed if it isn't confirmed within PAYMENT_CONFIRMATION_TIMEOUT_MINUTES; follow it via GET /jobs/:id.
 func CreateJob(c *fiber.Ctx) error {
 	userID := c.Locals("user_id")
 	if userID == nil {
@@ -55,79 +142,228 @@ func CreateJob(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
 	}
 
+	// Company jobs are authorized by the caller's company role (checked by services.CreateJob);
+	// personal jobs need the platform jobs:create permission
+	if req.CompanyID == "" {
+		roles, _ := c.Locals("roles").([]string)
+		if !services.HasPermission(roles, services.PermJobsCreate) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "missing permission " + services.PermJobsCreate})
+		}
+	}
+
 	if req.Title == "" || req.Description == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "title and description required"})
 	}
 
-	if req.PaymentTxHash == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "payment_tx_hash is required - blockchain payment must be completed first"})
+	if err := services.ValidateSalary(req.Salary); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := services.ResolvePlace(&req.Place); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := services.ValidateTaxonomy(req.Category, req.EmploymentType, req.Seniority); err != nil {
+		if errors.Is(err, services.ErrInvalidTaxonomy) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to validate job classification"})
 	}
 
-	jobID, err := services.CreateJob(req.Title, req.Description, req.Skills, req.Salary, req.Location, uidStr, req.PaymentTxHash)
+	cfg := config.LoadConfig()
+	jobID, status, err := services.CreateJob(services.CreateJobInput{
+		Title:          req.Title,
+		Description:    req.Description,
+		Skills:         req.Skills,
+		Salary:         req.Salary,
+		Location:       req.Location,
+		Place:          req.Place,
+		Category:       req.Category,
+		EmploymentType: req.EmploymentType,
+		Seniority:      req.Seniority,
+		UserID:         uidStr,
+		CompanyID:      req.CompanyID,
+		PaymentTxHash:  req.PaymentTxHash,
+		PaymentMethod:  req.PaymentMethod,
+		ChainID:        cfg.ChainID,
+		Plan:           req.Plan,
+		Status:         req.Status,
+		Confirmations:  cfg.PaymentConfirmations,
+	})
 	if err != nil {
 		// Return appropriate error messages for different failure scenarios
 		errorMsg := err.Error()
-		if errorMsg == "invalid transaction hash format" {
+		switch err {
+		case services.ErrInvalidTxHash:
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid transaction hash format - must be a valid Ethereum transaction hash"})
+		case services.ErrCompanyNotFound, services.ErrPlanNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": errorMsg})
+		case services.ErrNotCompanyMember, services.ErrCompanyRole:
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": errorMsg})
+		case services.ErrNoCredits:
+			return c.Status(fiber.StatusPaymentRequired).JSON(fiber.Map{"error": errorMsg})
+		}
+		if errors.Is(err, services.ErrPaymentUnavailable) {
+			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": services.ErrPaymentUnavailable.Error()})
+		}
+		var consumed *services.PaymentConsumedError
+		if errors.As(err, &consumed) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": errorMsg})
 		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": errorMsg})
 	}
 
+	if status == services.JobStatusPendingPayment {
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+			"id":      jobID,
+			"status":  status,
+			"message": "Job saved; it goes live once the payment transaction is confirmed",
+		})
+	}
+
+	message := "Job posted successfully with blockchain payment confirmation"
+	if req.PaymentTxHash == "" {
+		message = "Job posted successfully using a posting credit"
+	}
+
 	// Respond with created job id
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"id":      jobID,
-		"message": "Job posted successfully with blockchain payment confirmation",
+		"status":  status,
+		"message": message,
 	})
 }
 
 // ListJobs handles public job listing (GET /jobs).
-// No authentication required - returns all available job postings.
-// Supports filtering by skill, location.
+// No authentication required - returns published, unexpired job postings.
+// Supports keyword search and filtering by skill, location.
 //
 // Query Parameters:
+// - ?q=senior go backend remote - Keyword search; results ranked by relevance (title matches weigh most)
 // - ?skill=go - Filter by required skill
 // - ?location=remote - Filter by location (case-insensitive, partial match)
+// - ?salary_min=100000 - Jobs whose salary range reaches at least this amount
+// - ?salary_max=150000 - Jobs whose salary range starts at or below this amount
+// - ?salary_period=monthly - Period of salary_min/salary_max (default: yearly); compared on yearly equivalents
+// - ?currency=USD - Filter by salary currency (amounts are not converted between currencies)
+// - ?category=engineering,data - Any of these categories
+// - ?employment_type=full_time,contract - Any of these employment types
+// - ?seniority=senior,lead - Any of these seniority levels
+// - ?work_arrangement=remote,hybrid - Any of remote, hybrid, onsite
+// - ?country=DE,FR - Any of these ISO country codes
+// - ?city=Berlin - Exact city (case-insensitive)
+// - ?tz_min=0&tz_max=3 - Jobs whose UTC offset is within this range (hours)
+// - ?near=52.52,13.405&radius_km=30 - Jobs within radius_km (default: 50) of a point or gazetteer city (?near=Berlin)
 // - ?limit=20 - Number of jobs to return (default: 50, max: 100)
+// - ?cursor=... - next_cursor from the previous page
+// - ?status=draft - Owner view: the caller's own jobs in this status ("all" for every status, token required)
 //
 // Examples:
 // - GET /jobs - All jobs
 // - GET /jobs?skill=react&location=remote - React jobs in Remote locations
 // - GET /jobs?location=New%20York&limit=10 - First 10 jobs in New York
+// - GET /jobs?status=expired - The caller's expired jobs
+// - GET /jobs?work_arrangement=remote&tz_min=0&tz_max=3 - Remote jobs in EU timezones
+// - GET /jobs?work_arrangement=onsite&near=Munich&radius_km=30 - Onsite jobs within 30 km of Munich
+// - GET /jobs?q=senior%20go%20backend - Best matches first, with title_highlight and snippet
 //
-// Returns: Array of jobs ordered by newest first (created_at DESC)
+// Returns: { items: [...], next_cursor } with jobs ordered by relevance when q is set,
+// otherwise newest first (created_at DESC). next_cursor is null on the last page.
 func ListJobs(c *fiber.Ctx) error {
-	limit := c.QueryInt("limit", 50)
-	if limit > 100 {
-		limit = 100
+	filter := services.JobFilter{
+		Limit:        pageLimit(c),
+		Cursor:       c.Query("cursor"),
+		Skill:        c.Query("skill"),
+		Location:     c.Query("location"),
+		Query:        strings.TrimSpace(c.Query("q")),
+		Currency:     c.Query("currency"),
+		SalaryPeriod: c.Query("salary_period"),
+	}
+
+	for param, dst := range map[string]*float64{"salary_min": &filter.SalaryMin, "salary_max": &filter.SalaryMax} {
+		if v := c.Query(param); v != "" {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil || n < 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid " + param})
+			}
+			*dst = n
+		}
 	}
-	if limit < 1 {
-		limit = 1
+	if filter.SalaryPeriod != "" && !services.IsValidSalaryPeriod(filter.SalaryPeriod) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid salary_period"})
 	}
 
-	skill := c.Query("skill")
-	location := c.Query("location")
+	filter.Categories = splitList(c.Query("category"))
+	for _, v := range splitList(c.Query("employment_type")) {
+		if !services.IsValidEmploymentType(v) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid employment_type"})
+		}
+		filter.EmploymentTypes = append(filter.EmploymentTypes, v)
+	}
+	for _, v := range splitList(c.Query("seniority")) {
+		if !services.IsValidSeniority(v) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid seniority"})
+		}
+		filter.SeniorityLevels = append(filter.SeniorityLevels, v)
+	}
 
-	// If filters provided, use filtered query
-	if skill != "" || location != "" {
-		jobs, err := services.ListJobsWithFilters(limit, skill, location, 0)
+	filter.City = c.Query("city")
+	for _, v := range splitList(c.Query("work_arrangement")) {
+		v = strings.ToLower(v)
+		if !services.IsValidWorkArrangement(v) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid work_arrangement"})
+		}
+		filter.WorkArrangements = append(filter.WorkArrangements, v)
+	}
+	for _, v := range splitList(c.Query("country")) {
+		filter.Countries = append(filter.Countries, strings.ToUpper(v))
+	}
+	for param, dst := range map[string]**float64{"tz_min": &filter.UTCOffsetMin, "tz_max": &filter.UTCOffsetMax} {
+		if v := c.Query(param); v != "" {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil || n < -12 || n > 14 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid " + param})
+			}
+			*dst = &n
+		}
+	}
+	if near := c.Query("near"); near != "" {
+		lat, lng, err := services.ParseNear(near)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to list jobs"})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		radius, err := strconv.ParseFloat(c.Query("radius_km", "50"), 64)
+		if err != nil || radius <= 0 || radius > 20000 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid radius_km"})
+		}
+		filter.Near = &services.Coordinates{Lat: lat, Lng: lng}
+		filter.RadiusKm = radius
+	}
+
+	// Owner view: ?status= lists the caller's own jobs
+	if status := c.Query("status"); status != "" {
+		userID, ok := c.Locals("user_id").(string)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "status filter requires authentication"})
+		}
+		if status != "all" && !services.IsValidJobStatus(status) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid status"})
 		}
-		if jobs == nil {
-			jobs = []*models.Job{}
+		filter.OwnerID = userID
+		if status != "all" {
+			filter.Status = status
 		}
-		return c.JSON(jobs)
 	}
 
-	// Otherwise use basic list
-	jobs, err := services.ListJobs(limit)
+	jobs, next, err := services.ListJobsWithFilters(filter)
 	if err != nil {
+		if err == utils.ErrInvalidCursor {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid cursor"})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to list jobs"})
 	}
 	if jobs == nil {
 		jobs = []*models.Job{}
 	}
-	return c.JSON(jobs)
+	return c.JSON(pageResponse(jobs, next))
 }
 
 // GetJob handles job detail retrieval with skill match scoring (GET /jobs/:id).
@@ -135,8 +371,13 @@ func ListJobs(c *fiber.Ctx) error {
 // authenticated user's skills compared to job requirements.
 //
 // Requires: Authorization: Bearer <token>
-// Returns: { job: {...}, match_score: 85 }
+// Returns: { job: {...}, match_score: 85, saved: false, payment: {...} }
 // - match_score: 0-100% indicating how well user's skills match the job
+// - saved: whether the user has bookmarked the job (POST /jobs/:id/save)
+// - payment: posting payment state (status, confirmations, required_confirmations, failure_reason); only for the job's poster and company managers
+//
+// Draft, pending_payment and payment_failed jobs return 404 unless the caller posted the job
+// or is a member of its company. Closed jobs stay reachable by ID.
 func GetJob(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
@@ -147,8 +388,8 @@ func GetJob(c *fiber.Ctx) error {
 	userID := c.Locals("user_id")
 	uidStr := userID.(string)
 
-	// Get the job
-	job, err := services.GetJobByID(id)
+	// Get the job; unlisted jobs (draft, pending_payment, payment_failed) only for those managing or viewing them
+	job, err := services.GetVisibleJob(id, uidStr)
 	if err != nil {
 		if err == services.ErrJobNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "job not found"})
@@ -168,9 +409,325 @@ func GetJob(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to compute match score"})
 	}
 
+	saved, err := services.IsJobSaved(job.ID.String(), uidStr)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch job"})
+	}
+
+	// Count the view for the recruiter dashboard (the poster's own views are ignored)
+	if job.UserID.String() != uidStr {
+		if err := services.RecordJobEvent(job.ID.String(), uidStr, services.JobEventView); err != nil {
+			log.Println("record job view failed:", err)
+		}
+	}
+
+	// Payment state is only shown to those managing the job
+	payment, err := services.GetJobPaymentState(job.ID.String(), uidStr, config.LoadConfig().PaymentConfirmations)
+	if err != nil && err != services.ErrNotJobOwner {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch job"})
+	}
+
 	// Return job with match score
 	return c.JSON(jobWithScoreResponse{
 		Job:        job,
 		MatchScore: score,
+		Saved:      saved,
+		Payment:    payment,
 	})
 }
+
+// jobOwnerError maps owner-only service errors to HTTP responses.
+func jobOwnerError(c *fiber.Ctx, err error, fallback string) error {
+	switch err {
+	case services.ErrJobNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "job not found"})
+	case services.ErrNotJobOwner:
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrInvalidStatus, services.ErrInvalidTxHash, services.ErrPaymentRequired,
+		services.ErrPaymentNotFound, services.ErrPaymentPending, services.ErrWalletRequired:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrStatusTransition:
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, services.ErrTxHashReused) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, services.ErrInvalidSalary) || errors.Is(err, services.ErrInvalidLocation) || errors.Is(err, services.ErrInvalidTaxonomy) ||
+		errors.Is(err, services.ErrPaymentRejected) || errors.Is(err, services.ErrUnknownPaymentMethod) ||
+		errors.Is(err, services.ErrPlanMethodUnavailable) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, services.ErrPaymentUnavailable) {
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": services.ErrPaymentUnavailable.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fallback})
+}
+
+// UpdateJob handles job edits by the poster (PUT /jobs/:id).
+// Supports partial updates - only provided fields are modified.
+// Structured location fields (work_arrangement, country, city, latitude, longitude,
+// utc_offset) are replaced together if any of them is present.
+//
+// Requires: Authorization: Bearer <token> (job poster, or owner/recruiter of its company)
+// Request body (all fields optional):
+//
+//	{
+//	  "title": "Staff Go Developer",
+//	  "description": "Updated description...",
+//	  "location": "Remote",
+//	  "work_arrangement": "remote",
+//	  "country": "DE",
+//	  "salary": { "min": 150000, "max": 180000, "currency": "USD", "period": "yearly" },
+//	  "skills": ["go", "kubernetes"]
+//	}
+//
+// Response on success (200 OK): the updated job
+//
+// Error responses:
+// - 400: Invalid request or no updates provided
+// - 403: Caller may not manage this job
+// - 404: Job not found
+func UpdateJob(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	var req updateJobRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	updates := make(map[string]interface{})
+	if req.Title != nil {
+		if *req.Title == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "title cannot be empty"})
+		}
+		updates["title"] = *req.Title
+	}
+	if req.Description != nil {
+		if *req.Description == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "description cannot be empty"})
+		}
+		updates["description"] = *req.Description
+	}
+	if req.Salary != nil {
+		if err := services.ValidateSalary(req.Salary); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		updates["salary"] = req.Salary
+	}
+	if req.Location != nil {
+		updates["location"] = *req.Location
+	}
+	if req.Skills != nil {
+		updates["skills"] = req.Skills
+	}
+	if req.Category != nil {
+		updates["category"] = *req.Category
+	}
+	if req.EmploymentType != nil {
+		updates["employment_type"] = *req.EmploymentType
+	}
+	if req.Seniority != nil {
+		updates["seniority"] = *req.Seniority
+	}
+	if req.Place != nil {
+		if err := services.ResolvePlace(req.Place); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		updates["place"] = req.Place
+	}
+
+	if len(updates) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "no updates provided"})
+	}
+
+	if err := services.UpdateJob(id, uidStr, updates); err != nil {
+		return jobOwnerError(c, err, "failed to update job")
+	}
+
+	job, err := services.GetJobByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch updated job"})
+	}
+	return c.JSON(job)
+}
+
+// CloseJob handles closing a job posting (POST /jobs/:id/close).
+// Closed jobs disappear from GET /jobs but remain reachable by ID.
+//
+// Requires: Authorization: Bearer <token> (job poster, or owner/recruiter of its company)
+// Response on success (200 OK): { "id": "job-uuid", "status": "closed" }
+//
+// Error responses:
+// - 403: Caller may not manage this job
+// - 404: Job not found
+// - 409: Job is filled or already closed
+func CloseJob(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	if err := services.CloseJob(id, uidStr); err != nil {
+		return jobOwnerError(c, err, "failed to close job")
+	}
+
+	return c.JSON(fiber.Map{"id": id, "status": services.JobStatusClosed})
+}
+
+// DeleteJob handles permanent deletion of a job posting (DELETE /jobs/:id).
+// Applications to the job are deleted with it.
+//
+// Requires: Authorization: Bearer <token> (job poster, or owner/recruiter of its company)
+// Response on success: 204 No Content
+func DeleteJob(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	if err := services.DeleteJob(id, uidStr); err != nil {
+		return jobOwnerError(c, err, "failed to delete job")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// SetJobStatus handles owner status changes (PUT /jobs/:id/status).
+//
+// Requires: Authorization: Bearer <token> (job poster, or owner/recruiter of its company)
+// Request body: { "status": "paused" }
+//
+// Allowed transitions:
+// - draft -> published (starts the expiry clock), closed
+// - published -> paused, filled, closed
+// - paused -> published, filled, closed
+// - expired -> filled, closed (use POST /jobs/:id/renew to republish)
+//
+// Response on success (200 OK): the updated job
+//
+// Error responses:
+// - 400: Unknown status
+// - 403: Caller may not manage this job
+// - 404: Job not found
+// - 409: Transition not allowed from the current status
+func SetJobStatus(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	var req jobStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	if err := services.SetJobStatus(id, uidStr, req.Status); err != nil {
+		return jobOwnerError(c, err, "failed to update job status")
+	}
+
+	job, err := services.GetJobByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch updated job"})
+	}
+	return c.JSON(job)
+}
+
+// RenewJob handles extending a job listing (POST /jobs/:id/renew).
+// Expired jobs are republished; published and paused jobs get a later expires_at.
+// Each renewal adds the plan's duration_days, and a payment must cover the plan's current price.
+//
+// Requires: Authorization: Bearer <token> (job poster, or owner/recruiter of its company)
+// Request body: { "payment_tx_hash": "0x...(66 chars)", "payment_method": "evm_native" }
+// - payment_tx_hash is required unless JOB_RENEWAL_REQUIRES_PAYMENT=false
+// - payment_method is optional (default "evm_native"), as for POST /jobs
+// - It must not have been used for this job before
+//
+// Response on success (200 OK): the renewed job
+//
+// Error responses:
+// - 400: Missing or malformed payment_tx_hash, or unsupported payment_method
+// - 403: Caller may not manage this job
+// - 404: Job not found
+// - 409: Job can't be renewed from its status, or tx hash already used
+func RenewJob(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	var req renewJobRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+		}
+	}
+
+	cfg := config.LoadConfig()
+	job, err := services.RenewJob(id, uidStr, req.PaymentTxHash, req.PaymentMethod, cfg.ChainID, cfg.JobRenewalRequiresPayment)
+	if err != nil {
+		return jobOwnerError(c, err, "failed to renew job")
+	}
+	return c.JSON(job)
+}
+
+// splitList splits a comma-separated query value, dropping empty entries.
+func splitList(v string) []string {
+	var out []string
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// GetJobTaxonomy returns the allowed job classification values (GET /jobs/taxonomy).
+// No authentication required.
+//
+// Returns:
+//
+//	{
+//	  "categories": [{ "slug": "engineering", "name": "Engineering" }, ...],
+//	  "employment_types": ["full_time", "part_time", "contract", ...],
+//	  "seniority_levels": ["intern", "junior", "mid", "senior", ...]
+//	}
+func GetJobTaxonomy(c *fiber.Ctx) error {
+	taxonomy, err := services.GetTaxonomy()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch taxonomy"})
+	}
+	return c.JSON(taxonomy)
+}
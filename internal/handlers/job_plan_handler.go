@@ -0,0 +1,169 @@
+// Job plan handler contains the pricing plan endpoints.
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+)
+
+// jobPlanRequest represents the JSON payload for creating or updating a pricing plan.
+// On update all fields are optional; only provided fields are modified.
+type jobPlanRequest struct {
+	Slug         string            `json:"slug,omitempty"`
+	Name         *string           `json:"name,omitempty"`
+	PriceWei     *string           `json:"price_wei,omitempty"`
+	Prices       map[string]string `json:"prices,omitempty"`
+	DurationDays *int              `json:"duration_days,omitempty"`
+	Perks        []string          `json:"perks,omitempty"`
+	Active       *bool             `json:"active,omitempty"`
+	SortOrder    *int              `json:"sort_order,omitempty"`
+}
+
+// ListJobPlans handles the public price list (GET /plans).
+// No authentication required.
+//
+// Returns: Array of active plans in display order
+// [ { slug: "basic", name: "Basic", price_wei: "1000000000000000", prices: { erc20: "2000000" }, duration_days: 30, perks: [...], active: true, sort_order: 1 }, ... ]
+func ListJobPlans(c *fiber.Ctx) error {
+	return listJobPlans(c, false)
+}
+
+// AdminListJobPlans handles the admin plan list including inactive plans (GET /admin/plans).
+//
+// Requires: Authorization: Bearer <token> (admin)
+func AdminListJobPlans(c *fiber.Ctx) error {
+	return listJobPlans(c, true)
+}
+
+func listJobPlans(c *fiber.Ctx, includeInactive bool) error {
+	plans, err := services.ListJobPlans(includeInactive)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch plans"})
+	}
+	if plans == nil {
+		plans = []models.JobPlan{}
+	}
+	return c.JSON(plans)
+}
+
+// CreateJobPlan handles adding a pricing plan (POST /admin/plans).
+//
+// Requires: Authorization: Bearer <token> (admin)
+// Request body:
+//
+//	{
+//	  "slug": "spotlight",
+//	  "name": "Spotlight",
+//	  "price_wei": "8000000000000000",
+//	  "prices": { "erc20": "15000000" },
+//	  "duration_days": 60,
+//	  "perks": ["Featured badge", "Pinned to the top for 7 days"],
+//	  "active": true,
+//	  "sort_order": 3
+//	}
+//
+// Response on success (201 Created): the plan
+//
+// Error responses:
+// - 400: Invalid fields
+// - 409: Slug already exists
+func CreateJobPlan(c *fiber.Ctx) error {
+	var req jobPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	plan := models.JobPlan{Slug: req.Slug, Prices: req.Prices, Perks: req.Perks, Active: true}
+	if req.Name != nil {
+		plan.Name = *req.Name
+	}
+	if req.PriceWei != nil {
+		plan.PriceWei = *req.PriceWei
+	}
+	if req.DurationDays != nil {
+		plan.DurationDays = *req.DurationDays
+	}
+	if req.Active != nil {
+		plan.Active = *req.Active
+	}
+	if req.SortOrder != nil {
+		plan.SortOrder = *req.SortOrder
+	}
+
+	created, err := services.CreateJobPlan(plan)
+	if err != nil {
+		return jobPlanError(c, err, "failed to create plan")
+	}
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// UpdateJobPlan handles editing a pricing plan (PUT /admin/plans/:slug).
+// Price and duration changes only affect new purchases. Plans can't be deleted;
+// set "active": false to stop offering one.
+//
+// Requires: Authorization: Bearer <token> (admin)
+// Request body (all fields optional): { "name", "price_wei", "prices", "duration_days", "perks", "active", "sort_order" }
+//
+// Response on success (200 OK): the updated plan
+//
+// Error responses:
+// - 400: Invalid fields
+// - 404: Plan not found
+func UpdateJobPlan(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+	if slug == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "slug required"})
+	}
+
+	var req jobPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.PriceWei != nil {
+		updates["price_wei"] = *req.PriceWei
+	}
+	if req.Prices != nil {
+		updates["prices"] = req.Prices
+	}
+	if req.DurationDays != nil {
+		updates["duration_days"] = *req.DurationDays
+	}
+	if req.Perks != nil {
+		updates["perks"] = req.Perks
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+	if req.SortOrder != nil {
+		updates["sort_order"] = *req.SortOrder
+	}
+
+	plan, err := services.UpdateJobPlan(slug, updates)
+	if err != nil {
+		return jobPlanError(c, err, "failed to update plan")
+	}
+	return c.JSON(plan)
+}
+
+// jobPlanError maps pricing plan service errors to HTTP responses.
+func jobPlanError(c *fiber.Ctx, err error, fallback string) error {
+	switch err {
+	case services.ErrPlanNotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrPlanExists:
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if errors.Is(err, services.ErrInvalidPlan) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fallback})
+}
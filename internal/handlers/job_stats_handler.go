@@ -0,0 +1,111 @@
+// Job stats handler contains the recruiter dashboard endpoints.
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
+)
+
+// jobWithStatsResponse represents a job with its dashboard counters.
+type jobWithStatsResponse struct {
+	Job   *models.Job     `json:"job"`
+	Stats models.JobStats `json:"stats"`
+}
+
+// MyJobs handles the recruiter dashboard listing (GET /me/jobs).
+// Lists the caller's own jobs in any status with aggregated counters.
+//
+// Requires: Authorization: Bearer <token>
+// Query Parameters:
+// - ?status=published - Only jobs in this status (default: all)
+// - ?limit=20, ?cursor=... - Pagination as in GET /jobs
+//
+// Returns: { items: [{ job: {...}, stats: { views, unique_viewers, saves, applications, avg_match_score } }], next_cursor }
+func MyJobs(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	status := c.Query("status")
+	if status == "all" {
+		status = ""
+	}
+	if status != "" && !services.IsValidJobStatus(status) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid status"})
+	}
+
+	jobs, stats, next, err := services.ListMyJobsWithStats(uidStr, status, pageLimit(c), c.Query("cursor"))
+	if err != nil {
+		if err == utils.ErrInvalidCursor {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid cursor"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to list jobs"})
+	}
+
+	items := make([]jobWithStatsResponse, 0, len(jobs))
+	for _, job := range jobs {
+		s, ok := stats[job.ID]
+		if !ok {
+			s.JobID = job.ID
+		}
+		items = append(items, jobWithStatsResponse{Job: job, Stats: s})
+	}
+	return c.JSON(pageResponse(items, next))
+}
+
+// GetJobStats handles the per-job daily time series (GET /jobs/:id/stats).
+//
+// Requires: Authorization: Bearer <token> (job poster or a member of its company)
+// Query Parameters:
+// - ?days=30 - Length of the series ending today (default: 30, max: 365)
+//
+// Returns:
+//
+//	{
+//	  "job_id": "job-uuid",
+//	  "totals": { "views": 120, "unique_viewers": 80, "saves": 9, "applications": 4, "avg_match_score": 71.5 },
+//	  "days": [ { "date": "2025-01-01", "views": 10, "unique_viewers": 8, "saves": 1, "applications": 0 }, ... ]
+//	}
+//
+// Error responses:
+// - 400: Invalid days
+// - 403: Caller may not view this job's stats
+// - 404: Job not found
+func GetJobStats(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	days, err := strconv.Atoi(c.Query("days", "30"))
+	if err != nil || days < 1 || days > services.MaxJobStatsDays {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "days must be between 1 and " + strconv.Itoa(services.MaxJobStatsDays)})
+	}
+
+	totals, series, err := services.GetJobStats(id, uidStr, days)
+	if err != nil {
+		return jobOwnerError(c, err, "failed to fetch job stats")
+	}
+	if series == nil {
+		series = []models.JobStatsDay{}
+	}
+
+	return c.JSON(fiber.Map{
+		"job_id": totals.JobID,
+		"totals": totals,
+		"days":   series,
+	})
+}
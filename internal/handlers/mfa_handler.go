@@ -0,0 +1,192 @@
+// MFA handler contains the two-factor authentication (TOTP) endpoints.
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/config"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
+)
+
+// mfaCodeRequest represents the JSON payload carrying an authenticator or recovery code.
+type mfaCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// mfaVerifyRequest represents the JSON payload for the second step of a login.
+type mfaVerifyRequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+// mfaDisableRequest represents the JSON payload for turning two-factor authentication off.
+type mfaDisableRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+// SetupTOTP handles starting two-factor enrollment (POST /me/2fa/setup).
+// Nothing changes for login until the code is confirmed with POST /me/2fa/enable;
+// calling setup again replaces an unconfirmed secret.
+//
+// Requires: Authorization: Bearer <token>
+//
+// Response on success (200 OK):
+// { "secret": "JBSWY3DPEHPK3PXP...", "otpauth_uri": "otpauth://totp/Job%20Portal:john@example.com?secret=...&issuer=Job%20Portal&algorithm=SHA1&digits=6&period=30" }
+//
+// Error responses:
+// - 409: Two-factor authentication already enabled
+func SetupTOTP(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+
+	cfg := config.LoadConfig()
+	secret, uri, err := services.BeginTOTPSetup(userID, cfg.TOTPIssuer)
+	if err != nil {
+		if err == services.ErrMFAAlreadyEnabled {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to start two-factor setup"})
+	}
+
+	return c.JSON(fiber.Map{"secret": secret, "otpauth_uri": uri})
+}
+
+// EnableTOTP handles confirming two-factor enrollment (POST /me/2fa/enable).
+//
+// Requires: Authorization: Bearer <token>
+// Request body: { "code": "123456" } (current code from the authenticator app)
+//
+// Response on success (200 OK):
+// { "enabled": true, "recovery_codes": ["abcde-fghij", ...] }
+// The 10 recovery codes each work once in place of a code and are shown only now.
+//
+// Error responses:
+// - 400: Setup not started, or wrong code
+// - 409: Already enabled
+func EnableTOTP(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+
+	var req mfaCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request"})
+	}
+	if req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "code required"})
+	}
+
+	codes, err := services.EnableTOTP(userID, req.Code)
+	if err != nil {
+		switch err {
+		case services.ErrMFASetupRequired, services.ErrInvalidMFACode:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		case services.ErrMFAAlreadyEnabled:
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to enable two-factor authentication"})
+	}
+
+	return c.JSON(fiber.Map{"enabled": true, "recovery_codes": codes})
+}
+
+// DisableTOTP handles turning two-factor authentication off (POST /me/2fa/disable).
+// The user re-authenticates with their password and a current or recovery code.
+//
+// Requires: Authorization: Bearer <token>
+// Request body: { "password": "secure_password", "code": "123456" }
+//
+// Response on success (200 OK):
+// { "enabled": false }
+//
+// Error responses:
+// - 400: Missing fields, two-factor not enabled, or wrong code
+// - 403: Wrong password
+// - 429: Too many wrong codes
+func DisableTOTP(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+
+	var req mfaDisableRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request"})
+	}
+	if req.Password == "" || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "password and code required"})
+	}
+
+	if err := services.DisableTOTP(userID, req.Password, req.Code); err != nil {
+		return mfaError(c, err, "failed to disable two-factor authentication")
+	}
+
+	return c.JSON(fiber.Map{"enabled": false})
+}
+
+// VerifyMFALogin handles the second step of a login (POST /auth/2fa/verify).
+// No Authorization header; the mfa_token from POST /auth/login (or /auth/siwe/verify)
+// proves the first factor.
+//
+// Request body:
+//
+//	{ "mfa_token": "eyJhbGc...", "code": "123456" }
+//
+// code is the authenticator code, or one of the recovery codes (used up on success).
+//
+// Response on success (200 OK):
+// { "token": "eyJhbGc...", "refresh_token": "...", "expires_in": 900 }
+//
+// Error responses:
+// - 400: Missing fields
+// - 401: Invalid or expired mfa_token, or wrong code
+// - 429: Too many wrong codes (locked for 15 minutes)
+func VerifyMFALogin(c *fiber.Ctx) error {
+	var req mfaVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request"})
+	}
+	if req.MFAToken == "" || req.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "mfa_token and code required"})
+	}
+
+	cfg := config.LoadConfig()
+	userID, err := utils.ParseMFAChallengeToken(req.MFAToken, cfg.JWTSecret)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := services.VerifyMFACode(userID, req.Code); err != nil {
+		switch err {
+		case services.ErrInvalidMFACode, services.ErrMFANotEnabled:
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": services.ErrInvalidMFACode.Error()})
+		case services.ErrMFALocked:
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to verify code"})
+	}
+
+	tokens, err := newSession(c, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create token"})
+	}
+	return c.JSON(tokens)
+}
+
+// mfaError maps two-factor service errors to HTTP responses.
+func mfaError(c *fiber.Ctx, err error, fallback string) error {
+	switch err {
+	case services.ErrMFANotEnabled, services.ErrInvalidMFACode:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrWrongPassword:
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrMFALocked:
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fallback})
+}
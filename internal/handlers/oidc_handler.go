@@ -0,0 +1,101 @@
+// OIDC handler contains the social login (OpenID Connect) endpoints.
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/config"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+)
+
+// oidcCallbackRequest represents the JSON payload the frontend posts after the provider redirect.
+type oidcCallbackRequest struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+// ListOIDCProviders handles listing the configured social login providers (GET /auth/oidc).
+//
+// Returns: { "providers": ["google", "linkedin"] }
+func ListOIDCProviders(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"providers": services.OIDCProviders()})
+}
+
+// StartOIDCLogin handles starting a social login (GET /auth/oidc/:provider).
+//
+// The frontend sends the browser to authorization_url. The provider then redirects to
+// OIDC_REDIRECT_URL with ?code=&state=, and the frontend posts both to POST /auth/oidc/callback.
+//
+// Response on success (200 OK):
+// { "authorization_url": "https://accounts.google.com/o/oauth2/v2/auth?...", "state": "..." }
+//
+// Error responses:
+// - 404: Provider not configured
+func StartOIDCLogin(c *fiber.Ctx) error {
+	cfg := config.LoadConfig()
+	authURL, state, err := services.StartOIDCLogin(c.Params("provider"), cfg.OIDCRedirectURL)
+	if err != nil {
+		if err == services.ErrUnknownOIDCProvider {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to start login"})
+	}
+
+	return c.JSON(fiber.Map{"authorization_url": authURL, "state": state})
+}
+
+// OIDCCallback handles finishing a social login (POST /auth/oidc/callback).
+//
+// The identity is matched to an account already linked to it, or to the account with the
+// same email if both the provider and the account verified that email; otherwise a new account is created.
+// Two-factor authentication still applies to the signed-in account.
+//
+// Request body:
+//
+//	{ "code": "...", "state": "..." }
+//
+// Response on success (200 OK): as POST /auth/login
+// { "token": "eyJhbGc...", "refresh_token": "...", "expires_in": 900 }
+//
+// Error responses:
+// - 400: Missing fields, or unknown, expired or reused state
+// - 401: Provider rejected the code
+// - 409: An account uses the email, but the provider or the account's owner didn't verify it
+// - 422: Provider shared no email address
+// - 502: Provider unreachable
+func OIDCCallback(c *fiber.Ctx) error {
+	var req oidcCallbackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request"})
+	}
+	if req.Code == "" || req.State == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "code and state required"})
+	}
+
+	userID, err := services.CompleteOIDCLogin(c.Context(), req.Code, req.State)
+	if err != nil {
+		switch err {
+		case services.ErrOIDCState, services.ErrUnknownOIDCProvider:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": services.ErrOIDCState.Error()})
+		case services.ErrOIDCEmailUnverified, services.ErrOIDCAccountUnverified:
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		case services.ErrOIDCNoEmail:
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+		}
+		if errors.Is(err, services.ErrOIDCRejected) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": services.ErrOIDCRejected.Error()})
+		}
+		if errors.Is(err, services.ErrOIDCUnavailable) {
+			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": services.ErrOIDCUnavailable.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to sign in"})
+	}
+
+	tokens, err := signIn(c, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create token"})
+	}
+	return c.JSON(tokens)
+}
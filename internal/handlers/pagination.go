@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// pageResponse builds the envelope used by cursor-paginated list endpoints.
+//
+// Response format:
+//
+//	{
+//	  "items": [...],
+//	  "next_cursor": "eyJjIjoi..." // null on the last page
+//	}
+//
+// To fetch the next page, repeat the request with ?cursor=<next_cursor>.
+func pageResponse(items interface{}, nextCursor string) fiber.Map {
+	var next interface{}
+	if nextCursor != "" {
+		next = nextCursor
+	}
+	return fiber.Map{"items": items, "next_cursor": next}
+}
+
+// pageLimit reads ?limit= clamped to 1..100 (default: 50).
+func pageLimit(c *fiber.Ctx) int {
+	limit := c.QueryInt("limit", 50)
+	if limit > 100 {
+		limit = 100
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
@@ -0,0 +1,142 @@
+// Password handler contains the forgot/reset and change password endpoints.
+package handlers
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/config"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+)
+
+// forgotPasswordRequest represents the JSON payload for requesting a reset link.
+type forgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// resetPasswordRequest represents the JSON payload for setting a new password with a reset token.
+type resetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// changePasswordRequest represents the JSON payload for changing the password while signed in.
+type changePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// ForgotPassword handles requesting a password reset link (POST /auth/forgot-password).
+// No authentication required.
+//
+// The response is the same whether or not an account uses the email, so the
+// endpoint can't be used to find out who is registered.
+//
+// Request body:
+//
+//	{ "email": "john@example.com" }
+//
+// Response (200 OK):
+// { "message": "if an account uses this email, a reset link has been sent" }
+//
+// Error responses:
+// - 400: Missing email
+func ForgotPassword(c *fiber.Ctx) error {
+	var req forgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request"})
+	}
+	if req.Email == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "email required"})
+	}
+
+	cfg := config.LoadConfig()
+	if err := services.RequestPasswordReset(req.Email, cfg.PasswordResetURL, cfg.PasswordResetTTL); err != nil {
+		// Logged only: failing loudly would reveal that the account exists
+		log.Printf("password reset email: %v", err)
+	}
+
+	return c.JSON(fiber.Map{"message": "if an account uses this email, a reset link has been sent"})
+}
+
+// ResetPassword handles setting a new password with a reset token (POST /auth/reset-password).
+// No authentication required. The token works once; all of the user's sessions are
+// revoked, so they sign in again with the new password.
+//
+// Request body:
+//
+//	{ "token": "...", "password": "new_secure_password" }
+//
+// Response on success (200 OK):
+// { "message": "password has been reset; sign in with the new password" }
+//
+// Error responses:
+// - 400: Missing fields, password not 8-72 characters, or invalid, expired or used token
+// - 500: Internal server error
+func ResetPassword(c *fiber.Ctx) error {
+	var req resetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request"})
+	}
+	if req.Token == "" || req.Password == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "token and password required"})
+	}
+
+	if err := services.ResetPassword(req.Token, req.Password); err != nil {
+		switch err {
+		case services.ErrInvalidPassword, services.ErrInvalidResetToken:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to reset password"})
+	}
+
+	return c.JSON(fiber.Map{"message": "password has been reset; sign in with the new password"})
+}
+
+// ChangePassword handles changing the password of the signed-in user (PUT /me/password).
+// Every session, including the current one, is revoked; the response carries
+// tokens for a new session so this client stays signed in.
+//
+// Requires: Authorization: Bearer <token>
+// Request body:
+//
+//	{ "current_password": "old_password", "new_password": "new_secure_password" }
+//
+// Response on success (200 OK):
+// { "token": "eyJhbGc...", "refresh_token": "...", "expires_in": 900 }
+//
+// Error responses:
+// - 400: Missing fields or new password not 8-72 characters
+// - 403: Current password is incorrect
+// - 500: Internal server error
+func ChangePassword(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+
+	var req changePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request"})
+	}
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "current_password and new_password required"})
+	}
+
+	if err := services.ChangePassword(userID, req.CurrentPassword, req.NewPassword); err != nil {
+		switch err {
+		case services.ErrInvalidPassword:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		case services.ErrWrongPassword:
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to change password"})
+	}
+
+	tokens, err := newSession(c, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "password changed, but failed to create token; sign in again"})
+	}
+	return c.JSON(tokens)
+}
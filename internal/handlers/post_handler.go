@@ -3,6 +3,7 @@ package handlers
 import (
 	"github.com/Akshatt02/job-portal-backend/internal/models"
 	"github.com/Akshatt02/job-portal-backend/internal/services"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -51,36 +52,34 @@ func CreatePost(c *fiber.Ctx) error {
 	})
 }
 
-// GetPosts handles fetching all posts from the social feed (GET /posts).
-// No authentication required - returns all posts ordered by newest first.
+// GetPosts handles fetching posts from the social feed (GET /posts).
+// No authentication required - returns posts ordered by newest first.
 //
 // Optional Query Parameters:
 // - ?limit=10 (default: 50, max: 100)
+// - ?cursor=... - next_cursor from the previous page
 //
-// Returns: Array of posts with user details
-// [
+// Returns: Page of posts with user details (next_cursor is null on the last page)
 //
 //	{
-//	  "id": "post-uuid",
-//	  "user_id": "user-uuid",
-//	  "user_name": "John Doe",
-//	  "user_bio": "Software Engineer",
-//	  "content": "Just launched my new project...",
-//	  "created_at": "2025-02-10T10:30:00Z"
+//	  "items": [
+//	    {
+//	      "id": "post-uuid",
+//	      "user_id": "user-uuid",
+//	      "user_name": "John Doe",
+//	      "user_bio": "Software Engineer",
+//	      "content": "Just launched my new project...",
+//	      "created_at": "2025-02-10T10:30:00Z"
+//	    }
+//	  ],
+//	  "next_cursor": "eyJjIjoi..."
 //	}
-//
-// ]
 func GetPosts(c *fiber.Ctx) error {
-	limit := c.QueryInt("limit", 50)
-	if limit > 100 {
-		limit = 100
-	}
-	if limit < 1 {
-		limit = 1
-	}
-
-	posts, err := services.GetPosts(limit)
+	posts, next, err := services.GetPosts(pageLimit(c), c.Query("cursor"))
 	if err != nil {
+		if err == utils.ErrInvalidCursor {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid cursor"})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch posts"})
 	}
 
@@ -88,21 +87,28 @@ func GetPosts(c *fiber.Ctx) error {
 		posts = []models.Post{}
 	}
 
-	return c.JSON(posts)
+	return c.JSON(pageResponse(posts, next))
 }
 
-// GetUserPosts handles fetching all posts by a specific user (GET /posts/:user_id).
-// No authentication required - returns public user posts.
+// GetUserPosts handles fetching posts by a specific user (GET /posts/:user_id).
+// No authentication required - returns public user posts, newest first.
+//
+// Optional Query Parameters:
+// - ?limit=10 (default: 50, max: 100)
+// - ?cursor=... - next_cursor from the previous page
 //
-// Returns: Array of posts by the specified user
+// Returns: { items: [...], next_cursor } with posts by the specified user
 func GetUserPosts(c *fiber.Ctx) error {
 	userID := c.Params("user_id")
 	if userID == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "user_id required"})
 	}
 
-	posts, err := services.GetUserPosts(userID)
+	posts, next, err := services.GetUserPosts(userID, pageLimit(c), c.Query("cursor"))
 	if err != nil {
+		if err == utils.ErrInvalidCursor {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid cursor"})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch user posts"})
 	}
 
@@ -110,5 +116,5 @@ func GetUserPosts(c *fiber.Ctx) error {
 		posts = []models.Post{}
 	}
 
-	return c.JSON(posts)
+	return c.JSON(pageResponse(posts, next))
 }
@@ -38,7 +38,7 @@ func GetProfile(c *fiber.Ctx) error {
 // Returns the complete profile of the currently logged-in user.
 //
 // Requires: Authorization: Bearer <token>
-// Returns: { id, name, email, bio, linkedin_url, skills, wallet_address }
+// Returns: { id, name, email, bio, linkedin_url, skills, wallet_address, roles }
 func Me(c *fiber.Ctx) error {
 	userID := c.Locals("user_id")
 	if userID == nil {
@@ -50,6 +50,11 @@ func Me(c *fiber.Ctx) error {
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "user not found"})
 	}
+	// Current roles from the database; the access token may predate a grant
+	user.Roles, err = services.GetRoles(idStr)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch roles"})
+	}
 	return c.JSON(user)
 }
 
@@ -67,6 +72,9 @@ func Me(c *fiber.Ctx) error {
 //	  "skills": ["go", "react", "postgres"]
 //	}
 //
+// A different wallet_address is stored unverified (wallet_verified = false);
+// prove ownership with POST /auth/siwe/verify before paying for jobs.
+//
 // Response on success (200 OK): { message: "Profile updated successfully" }
 func UpdateProfile(c *fiber.Ctx) error {
 	userID := c.Locals("user_id")
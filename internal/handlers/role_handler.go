@@ -0,0 +1,106 @@
+// Role handler contains the platform role endpoints.
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+)
+
+// roleRequest represents the JSON payload for granting a role.
+type roleRequest struct {
+	Role string `json:"role"`
+}
+
+// GetUserRoles handles viewing a user's roles and their audit trail (GET /admin/users/:id/roles).
+//
+// Requires: Authorization: Bearer <token> (admin)
+//
+// Response on success (200 OK):
+//
+//	{
+//	  "user_id": "...",
+//	  "roles": ["candidate", "recruiter"],
+//	  "history": [ { "id": "...", "role": "recruiter", "action": "grant", "actor_id": "...", "created_at": "..." } ]
+//	}
+//
+// Error responses:
+// - 404: User not found
+func GetUserRoles(c *fiber.Ctx) error {
+	roles, err := services.GetUserRoles(c.Params("id"))
+	if err == services.ErrUserNotFound {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch roles"})
+	}
+	return c.JSON(roles)
+}
+
+// GrantUserRole handles granting a platform role (POST /admin/users/:id/roles).
+// Granting a role the user already has changes nothing. The grant is recorded with
+// the admin as actor and takes effect in the user's next access token.
+//
+// Requires: Authorization: Bearer <token> (admin)
+// Request body:
+//
+//	{ "role": "admin" }
+//
+// Response on success (200 OK): the user's roles and history as in GET /admin/users/:id/roles
+//
+// Error responses:
+// - 400: Invalid role
+// - 404: User not found
+func GrantUserRole(c *fiber.Ctx) error {
+	actorID, ok := c.Locals("user_id").(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+
+	var req roleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	if err := services.GrantRole(c.Params("id"), req.Role, actorID); err != nil {
+		return roleError(c, err, "failed to grant role")
+	}
+	return GetUserRoles(c)
+}
+
+// RevokeUserRole handles removing a platform role (DELETE /admin/users/:id/roles/:role).
+// The revoke is recorded with the admin as actor, and all of the user's sessions are
+// signed out so no access token keeps the role.
+//
+// Requires: Authorization: Bearer <token> (admin)
+//
+// Response on success: 204 No Content
+//
+// Error responses:
+// - 400: Invalid role
+// - 404: User doesn't have the role
+// - 409: Would leave the platform without an admin
+func RevokeUserRole(c *fiber.Ctx) error {
+	actorID, ok := c.Locals("user_id").(string)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+
+	if err := services.RevokeRole(c.Params("id"), c.Params("role"), actorID); err != nil {
+		return roleError(c, err, "failed to revoke role")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// roleError maps role service errors to HTTP responses.
+func roleError(c *fiber.Ctx, err error, fallback string) error {
+	switch err {
+	case services.ErrInvalidRole:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrUserNotFound, services.ErrRoleNotHeld:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case services.ErrLastAdmin:
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fallback})
+}
@@ -0,0 +1,123 @@
+// Saved job handler contains endpoints for bookmarking jobs.
+package handlers
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+)
+
+// saveJobRequest represents the JSON payload for saving a job.
+type saveJobRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// SaveJob handles bookmarking a job (POST /jobs/:id/save).
+// Saving an already saved job replaces its note.
+//
+// Requires: Authorization: Bearer <token>
+// Request body (optional):
+//
+//	{ "note": "Ask about visa sponsorship" }
+//
+// Response on success (200 OK): the saved job entry
+//
+// Error responses:
+// - 400: Invalid request body
+// - 404: Job not found
+// - 500: Database error
+func SaveJob(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	var req saveJobRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+		}
+	}
+
+	saved, created, err := services.SaveJob(id, uidStr, req.Note)
+	if err != nil {
+		if err == services.ErrJobNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "job not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to save job"})
+	}
+
+	if created {
+		if err := services.RecordJobEvent(saved.JobID.String(), uidStr, services.JobEventSave); err != nil {
+			log.Println("record job save failed:", err)
+		}
+	}
+
+	return c.JSON(saved)
+}
+
+// UnsaveJob handles removing a job from the user's saved jobs (DELETE /jobs/:id/save).
+//
+// Requires: Authorization: Bearer <token>
+// Response on success: 204 No Content
+//
+// Error responses:
+// - 404: Job is not saved
+// - 500: Database error
+func UnsaveJob(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	if err := services.UnsaveJob(id, uidStr); err != nil {
+		if err == services.ErrJobNotSaved {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to remove saved job"})
+	}
+
+	if err := services.RecordJobEvent(id, uidStr, services.JobEventUnsave); err != nil {
+		log.Println("record job unsave failed:", err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// MySavedJobs handles listing the current user's saved jobs (GET /me/saved-jobs).
+//
+// Requires: Authorization: Bearer <token>
+// Returns: Array of saved jobs with notes and job details, most recently saved first
+func MySavedJobs(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	saved, err := services.ListSavedJobs(uidStr)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch saved jobs"})
+	}
+
+	if saved == nil {
+		saved = []models.SavedJob{}
+	}
+
+	return c.JSON(saved)
+}
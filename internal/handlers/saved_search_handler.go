@@ -0,0 +1,165 @@
+// Saved search handler contains endpoints for saved job searches and their alerts.
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+)
+
+// savedSearchRequest represents the JSON payload for saving a search.
+// Filter fields use the same names and semantics as the GET /jobs query parameters.
+type savedSearchRequest struct {
+	Name     string `json:"name"`
+	Skill    string `json:"skill"`
+	Location string `json:"location"`
+	Query    string `json:"q"`
+}
+
+// CreateSavedSearch handles saving a job search as an alert (POST /me/saved-searches).
+// New published jobs matching the search show up in GET /me/alerts and are
+// delivered through the configured notifier (ALERT_NOTIFIER).
+//
+// Requires: Authorization: Bearer <token>
+// Request body:
+//
+//	{ "name": "Go in Berlin", "skill": "go", "location": "berlin", "q": "backend" }
+//
+// Response on success (201 Created): the saved search
+//
+// Error responses:
+// - 400: Missing name or no filters
+// - 500: Database error
+func CreateSavedSearch(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	var req savedSearchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	}
+
+	search, err := services.CreateSavedSearch(uidStr, req.Name, req.Skill, req.Location, req.Query)
+	if err != nil {
+		if err == services.ErrEmptySavedSearch {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to save search"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(search)
+}
+
+// MySavedSearches handles listing the current user's saved searches (GET /me/saved-searches).
+//
+// Requires: Authorization: Bearer <token>
+// Returns: Array of saved searches, newest first
+func MySavedSearches(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	searches, err := services.ListSavedSearches(uidStr)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch saved searches"})
+	}
+
+	if searches == nil {
+		searches = []models.SavedSearch{}
+	}
+
+	return c.JSON(searches)
+}
+
+// DeleteSavedSearch handles removing a saved search (DELETE /me/saved-searches/:id).
+// Its alerts are removed with it.
+//
+// Requires: Authorization: Bearer <token>
+// Response on success: 204 No Content
+//
+// Error responses:
+// - 404: Saved search not found
+// - 500: Database error
+func DeleteSavedSearch(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	if err := services.DeleteSavedSearch(id, uidStr); err != nil {
+		if err == services.ErrSavedSearchNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to delete saved search"})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// MyAlerts handles listing the current user's in-app job alerts (GET /me/alerts).
+//
+// Requires: Authorization: Bearer <token>
+// Query parameters:
+// - unread=true: Only return alerts not yet marked as read
+//
+// Returns: Array of alert hits with search name and job title, newest first (max 100)
+func MyAlerts(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	hits, err := services.ListAlertHits(uidStr, c.QueryBool("unread"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to fetch alerts"})
+	}
+
+	if hits == nil {
+		hits = []models.AlertHit{}
+	}
+
+	return c.JSON(hits)
+}
+
+// MarkAlertRead handles marking an alert as read (POST /me/alerts/:id/read).
+//
+// Requires: Authorization: Bearer <token>
+// Response on success: 204 No Content
+//
+// Error responses:
+// - 404: Alert not found
+// - 500: Database error
+func MarkAlertRead(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	}
+	uidStr := userID.(string)
+
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id required"})
+	}
+
+	if err := services.MarkAlertRead(id, uidStr); err != nil {
+		if err == services.ErrAlertNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to update alert"})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
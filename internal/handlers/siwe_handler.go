@@ -0,0 +1,117 @@
+// SIWE handler contains the Sign-In with Ethereum (EIP-4361) endpoints.
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Akshatt02/job-portal-backend/internal/config"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
+)
+
+// siweVerifyRequest represents the JSON payload for verifying a signed SIWE message.
+type siweVerifyRequest struct {
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
+
+// GetSIWENonce handles nonce issuance for Sign-In with Ethereum (GET /auth/siwe/nonce).
+// The nonce goes into the message's "Nonce:" field and can be used once.
+//
+// Returns: { nonce: "9f86d081884c7d65...", expires_at: "...", domain: "app.example.com", chain_id: 11155111 }
+// - domain and chain_id are the values the message must use
+func GetSIWENonce(c *fiber.Ctx) error {
+	nonce, expiresAt, err := services.CreateSIWENonce()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create nonce"})
+	}
+
+	cfg := config.LoadConfig()
+	return c.JSON(fiber.Map{
+		"nonce":      nonce,
+		"expires_at": expiresAt,
+		"domain":     cfg.SIWEDomain,
+		"chain_id":   cfg.ChainID,
+	})
+}
+
+// VerifySIWE handles signed Sign-In with Ethereum messages (POST /auth/siwe/verify).
+//
+// Without a token it logs in the account that verified the wallet.
+// With Authorization: Bearer <token> it links the wallet to that account as its
+// verified wallet_address (replacing any previous wallet).
+//
+// Request body:
+//
+//	{
+//	  "message": "app.example.com wants you to sign in with your Ethereum account:\n0xAbC...\n\n...",
+//	  "signature": "0x...(65 bytes, personal_sign)"
+//	}
+//
+// Response on success (200 OK):
+// - Login: { "token": "eyJhbGc...", "refresh_token": "...", "expires_in": 900, "wallet_address": "0xAbC..." }
+// - Login with two-factor authentication enabled: { "mfa_required": true, "mfa_token": "...", "mfa_expires_in": 300, "wallet_address": "0xAbC..." }
+// - Link: the updated user profile, with wallet_verified = true
+//
+// Error responses:
+// - 400: Malformed message or signature, wrong domain or chain ID, expired message or nonce
+// - 401: Signature doesn't match the message address, or (login) no account has verified the wallet
+// - 409: (link) Another account has already verified the wallet
+func VerifySIWE(c *fiber.Ctx) error {
+	var req siweVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request"})
+	}
+	if req.Message == "" || req.Signature == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "message and signature required"})
+	}
+
+	cfg := config.LoadConfig()
+	address, err := services.VerifySIWE(req.Message, req.Signature, cfg.SIWEDomain, cfg.ChainID)
+	if err != nil {
+		switch err {
+		case services.ErrSIWESigner:
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+		case utils.ErrInvalidSignature, services.ErrSIWEDomain, services.ErrSIWEChain,
+			services.ErrSIWEExpired, services.ErrSIWENonce:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if errors.Is(err, utils.ErrInvalidSIWEMessage) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to verify message"})
+	}
+
+	// Signed in: link the proven wallet to the current account
+	if userID, ok := c.Locals("user_id").(string); ok {
+		if err := services.LinkVerifiedWallet(userID, address); err != nil {
+			if err == services.ErrWalletTaken {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to link wallet"})
+		}
+		user, err := services.GetUserByID(userID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "user not found"})
+		}
+		return c.JSON(user)
+	}
+
+	id, err := services.LoginWithWallet(address)
+	if err != nil {
+		if err == services.ErrWalletNotLinked {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to sign in"})
+	}
+
+	tokens, err := signIn(c, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to create token"})
+	}
+	tokens["wallet_address"] = address
+
+	return c.JSON(tokens)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/Akshatt02/job-portal-backend/internal/config"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
 	"github.com/Akshatt02/job-portal-backend/pkg/utils"
 )
 
@@ -25,12 +26,14 @@ import (
 // 1. Check if Authorization header exists
 // 2. Parse "Bearer <token>" format
 // 3. Validate JWT signature using secret key
-// 4. Extract user ID from token claims
-// 5. Store user ID in Fiber context locals
-// 6. Call next handler
+// 4. Extract user ID, session ID and roles from token claims
+// 5. Reject the token if its session was revoked (logout) or has expired
+// 6. Store user ID, session ID and roles in Fiber context locals
+// 7. Call next handler
 //
 // Return Codes:
-// - 401 Unauthorized: Missing or invalid token
+// - 401 Unauthorized: Missing or invalid token, or revoked session
+// - 500 Internal Server Error: Session lookup failed
 // - 200 + Next Handler: Valid token, user ID set
 //
 // Usage:
@@ -42,10 +45,12 @@ import (
 // In handler:
 //
 //	userID := c.Locals("user_id").(string)
+//	sessionID := c.Locals("session_id").(string)
+//	roles := c.Locals("roles").([]string)
 //
 // Notes:
-// - JWT token created at login by handlers.Login
-// - Token contains user ID + expiration time
+// - JWT token created at login by handlers.Login and renewed by handlers.Refresh
+// - Token contains user ID + session ID + roles + expiration time (ACCESS_TOKEN_TTL_MINUTES)
 // - Frontend sends token in every protected request
 // - Middleware validates before handler executes
 func AuthRequired() fiber.Handler {
@@ -70,18 +75,156 @@ func AuthRequired() fiber.Handler {
 		// Load JWT secret from environment config
 		cfg := config.LoadConfig()
 
-		// Validate token signature and extract user ID
+		// Validate token signature and extract user ID, session ID and roles
 		// Returns error if signature invalid or token expired
-		userID, err := utils.ParseToken(tokenStr, cfg.JWTSecret)
+		claims, err := utils.ParseToken(tokenStr, cfg.JWTSecret)
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid token"})
 		}
 
-		// Store user ID in Fiber context locals
-		// Available in handler via: c.Locals("user_id")
-		c.Locals("user_id", userID)
+		// Tokens stay signed until they expire, so check the session wasn't revoked since
+		// (revoking a role revokes the user's sessions, so stale roles end here too)
+		active, err := services.IsSessionActive(claims.SessionID, claims.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check session"})
+		}
+		if !active {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "session revoked or expired"})
+		}
+
+		// Store user ID, session ID and roles in Fiber context locals
+		// Available in handler via: c.Locals("user_id"), c.Locals("session_id"), c.Locals("roles")
+		c.Locals("user_id", claims.UserID)
+		c.Locals("session_id", claims.SessionID)
+		c.Locals("roles", claims.Roles)
 
 		// Continue to next middleware/handler
 		return c.Next()
 	}
 }
+
+// AuthOptional is a Fiber middleware for public routes that behave differently
+// for signed-in users.
+//
+// If a valid "Authorization: Bearer <token>" header is present, the user ID, session ID
+// and roles are stored in c.Locals exactly like AuthRequired. Missing or invalid
+// tokens, and tokens of revoked sessions, are ignored and the request continues anonymously.
+//
+// Usage:
+//
+//	app.Get("/jobs", middleware.AuthOptional(), handlers.ListJobs)
+//
+// In handler:
+//
+//	if userID, ok := c.Locals("user_id").(string); ok { /* signed in */ }
+func AuthOptional() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		auth := c.Get("Authorization")
+		parts := strings.SplitN(auth, " ", 2)
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			return c.Next()
+		}
+
+		cfg := config.LoadConfig()
+		if claims, err := utils.ParseToken(parts[1], cfg.JWTSecret); err == nil {
+			if active, err := services.IsSessionActive(claims.SessionID, claims.UserID); err == nil && active {
+				c.Locals("user_id", claims.UserID)
+				c.Locals("session_id", claims.SessionID)
+				c.Locals("roles", claims.Roles)
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// RequireRole is a Fiber middleware that only lets users holding at least one of
+// the given roles through. Must run after AuthRequired, which sets c.Locals("roles").
+//
+// Roles come from the access token's "roles" claim, so no database lookup is made.
+// A role granted after the token was issued counts from the next POST /auth/refresh;
+// a revoked role ends the user's sessions right away.
+//
+// Return Codes:
+// - 401 Unauthorized: No authenticated user
+// - 403 Forbidden: User has none of the roles
+//
+// Usage:
+//
+//	admin := protected.Group("/admin", middleware.RequireRole(services.RoleAdmin))
+//	admin.Post("/plans", handlers.CreateJobPlan)
+func RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.Locals("user_id").(string); !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+
+		held, _ := c.Locals("roles").([]string)
+		for _, r := range held {
+			for _, want := range roles {
+				if r == want {
+					return c.Next()
+				}
+			}
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": strings.Join(roles, " or ") + " role required"})
+	}
+}
+
+// RequirePermission is a Fiber middleware that only lets users whose roles grant
+// perm through (see services.HasPermission). Must run after AuthRequired.
+//
+// Prefer it over RequireRole for features, so which roles may use them is decided
+// in one place (the services role-permission table).
+//
+// Return Codes:
+// - 401 Unauthorized: No authenticated user
+// - 403 Forbidden: None of the user's roles grants perm
+//
+// Usage:
+//
+//	protected.Post("/companies", middleware.RequirePermission(services.PermCompaniesCreate), handlers.CreateCompany)
+func RequirePermission(perm string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.Locals("user_id").(string); !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+
+		roles, _ := c.Locals("roles").([]string)
+		if !services.HasPermission(roles, perm) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "missing permission " + perm})
+		}
+
+		return c.Next()
+	}
+}
+
+// EmailVerifiedRequired is a Fiber middleware that only lets users with a verified
+// email address through. Must run after AuthRequired, which sets c.Locals("user_id").
+//
+// Return Codes:
+// - 401 Unauthorized: No authenticated user
+// - 403 Forbidden: Email address not verified yet
+//
+// Usage:
+//
+//	protected.Post("/jobs", middleware.EmailVerifiedRequired(), handlers.CreateJob)
+func EmailVerifiedRequired() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := c.Locals("user_id").(string)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+		}
+
+		verified, err := services.IsEmailVerified(userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to check email verification"})
+		}
+		if !verified {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": services.ErrEmailNotVerified.Error()})
+		}
+
+		return c.Next()
+	}
+}
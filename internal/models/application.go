@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Application represents a candidate's application to a job posting
+//
+// Fields:
+// - ID: Unique identifier (UUID), primary key in database
+// - JobID: UUID of the job being applied to
+// - UserID: UUID of the applying candidate
+// - CoverNote: Optional free-text note from the candidate
+// - ResumeURL: Optional reference to the candidate's resume (link or file key)
+// - MatchScore: AI match score (0-100) snapshotted when the application was created
+// - CreatedAt: Application timestamp
+//
+// Database Table: applications
+// - Unique constraint on (job_id, user_id): one application per candidate per job
+// - Foreign keys: JobID references jobs(id), UserID references users(id)
+//
+// API Usage:
+// - Returned by POST /jobs/:id/apply, GET /me/applications, GET /jobs/:id/applications
+// - MatchScore is nil if the AI service was unavailable at apply time
+// - JobTitle / ApplicantName are joined in for display and not stored
+type Application struct {
+	ID         uuid.UUID `json:"id"`
+	JobID      uuid.UUID `json:"job_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	CoverNote  string    `json:"cover_note,omitempty"`
+	ResumeURL  string    `json:"resume_url,omitempty"`
+	MatchScore *int      `json:"match_score,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	// Display details included for listing
+	JobTitle       string `json:"job_title,omitempty"`
+	ApplicantName  string `json:"applicant_name,omitempty"`
+	ApplicantEmail string `json:"applicant_email,omitempty"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Company represents an organization that posts jobs as a hiring team
+//
+// Fields:
+// - ID: Unique identifier (UUID), primary key in database
+// - Name: Company name (required)
+// - LogoURL: Optional logo image URL
+// - Website: Optional company website
+// - Description: Optional company profile text
+// - CreatedAt: Creation timestamp
+// - Role: The caller's role in the company (only set in GET /me/companies)
+//
+// Database Table: companies
+// - Jobs reference it through jobs.company_id
+// - Members are stored in company_members
+//
+// API Usage:
+// - Profile is public (GET /companies/:id); only owners can edit it
+type Company struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	LogoURL     string    `json:"logo_url,omitempty"`
+	Website     string    `json:"website,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	Role        string    `json:"role,omitempty"`
+}
+
+// CompanyMember represents a user's membership in a company
+//
+// Fields:
+// - CompanyID: UUID of the company
+// - UserID: UUID of the member
+// - Role: owner, recruiter or viewer
+// - CreatedAt: When the user joined
+// - Name / Email: Joined in from users for display and not stored
+//
+// Roles:
+// - owner: Edits the company profile, manages members, and manages all company jobs
+// - recruiter: Posts and manages company jobs and reviews applications
+// - viewer: Read-only access to company jobs and applications
+//
+// Database Table: company_members
+// - Primary key (company_id, user_id)
+type CompanyMember struct {
+	CompanyID uuid.UUID `json:"company_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	// Display details included for listing
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreditEntry represents one grant or debit in the posting credits ledger
+//
+// Fields:
+// - ID: Unique identifier (UUID)
+// - Plan: Pricing plan the credits post on (see JobPlan)
+// - Delta: Credits added (positive, purchases) or used (negative, job postings)
+// - Reason: "purchase" or "job_post"
+// - JobID: Job a credit was spent on (job_post only; nil once the job is deleted)
+// - PaymentTxHash: Payment that bought the credits (purchase only)
+// - ActorID: User who bought or spent the credits (differs per member for company credits)
+// - CreatedAt: When the entry was recorded
+//
+// Database Table: posting_credits
+// - Each entry belongs to either a user or a company
+// - Entries are never updated or deleted; the balance is the sum of Delta
+type CreditEntry struct {
+	ID            uuid.UUID  `json:"id"`
+	Plan          string     `json:"plan"`
+	Delta         int        `json:"delta"`
+	Reason        string     `json:"reason"`
+	JobID         *uuid.UUID `json:"job_id,omitempty"`
+	PaymentTxHash string     `json:"payment_tx_hash,omitempty"`
+	ActorID       *uuid.UUID `json:"actor_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// CreditBalance is the number of unused posting credits for one plan.
+type CreditBalance struct {
+	Plan    string `json:"plan"`
+	Credits int    `json:"credits"`
+}
+
+// Credits is the posting credit account of a user or a company
+//
+// Fields:
+// - CompanyID: Set for company accounts, omitted for personal ones
+// - Balances: Unused credits per plan (plans with no credits left are omitted)
+// - History: Ledger entries, newest first
+//
+// API Usage:
+// - Returned by GET /me/credits and POST /me/credits
+type Credits struct {
+	CompanyID *uuid.UUID      `json:"company_id,omitempty"`
+	Balances  []CreditBalance `json:"balances"`
+	History   []CreditEntry   `json:"history"`
+}
@@ -13,10 +13,18 @@ import (
 // - Title: Job position title (e.g., "Senior React Engineer")
 // - Description: Full job description including responsibilities
 // - Skills: Array of required skills for the job
-// - Salary: Compensation range or "Competitive"
-// - Location: Job location (remote, office address, etc.)
+// - Salary: Structured compensation range (nil if not disclosed)
+// - Location: Free-text location label (e.g., "Berlin, Germany (hybrid)")
+// - Place: Structured location - work arrangement, country, city, coordinates, UTC offset
+// - Category: Job category slug from job_categories (e.g., "engineering")
+// - EmploymentType: full_time, part_time, contract, internship, temporary or freelance
+// - Seniority: intern, junior, mid, senior, lead, principal or executive
 // - UserID: UUID of user who posted the job
+// - CompanyID: UUID of the company the job is posted for (nil for personal postings)
 // - PaymentTxHash: Sepolia ETH transaction hash proving payment
+// - Plan: Pricing plan slug chosen at creation (see JobPlan); sets the price and listing duration
+// - Status: draft, published, paused, expired, filled, closed, pending_payment or payment_failed
+// - ExpiresAt: When a published job stops being listed (nil for drafts)
 // - CreatedAt: Job posting timestamp
 //
 // Database Table: jobs
@@ -27,22 +35,79 @@ import (
 //
 // Blockchain Payment:
 // - PaymentTxHash is the Sepolia transaction hash from job creation
-// - Value: 0.001 Sepolia ETH sent to ADMIN_WALLET
+// - Value: at least the plan's price_wei sent to ADMIN_WALLET
 // - Serves as proof of payment / audit trail
-// - NOT verified on-chain (future enhancement)
+// - Verified on-chain (status, recipient, value, sender) when ETH_RPC_URL is configured
+// - Jobs whose payment isn't confirmed yet wait in pending_payment (see models.PaymentState)
 //
 // API Usage:
 // - Returned by GET /jobs, GET /jobs/:id, POST /jobs
 // - Match score computed by AI (not in this model, added in response)
+// - SearchRank/TitleHighlight/Snippet are only set for GET /jobs?q= results
+// - Highlights wrap matched terms in <mark>...</mark>; the text is NOT HTML-escaped
 // - Only users can POST jobs, anyone can GET (list/details)
+// - Only the poster (or the company's owners and recruiters) can PUT, close or DELETE a job
 type Job struct {
-	ID            uuid.UUID `json:"id"`
-	Title         string    `json:"title"`
-	Description   string    `json:"description"`
-	Skills        []string  `json:"skills,omitempty"`
-	Salary        string    `json:"salary,omitempty"`
-	Location      string    `json:"location,omitempty"`
-	UserID        uuid.UUID `json:"user_id"`
-	PaymentTxHash string    `json:"payment_tx_hash,omitempty"`
-	CreatedAt     time.Time `json:"created_at,omitempty"`
+	ID             uuid.UUID  `json:"id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Skills         []string   `json:"skills,omitempty"`
+	Salary         *Salary    `json:"salary,omitempty"`
+	Location       string     `json:"location,omitempty"`
+	Category       string     `json:"category,omitempty"`
+	EmploymentType string     `json:"employment_type,omitempty"`
+	Seniority      string     `json:"seniority,omitempty"`
+	UserID         uuid.UUID  `json:"user_id"`
+	CompanyID      *uuid.UUID `json:"company_id,omitempty"`
+	PaymentTxHash  string     `json:"payment_tx_hash,omitempty"`
+	Plan           string     `json:"plan"`
+	Status         string     `json:"status"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at,omitempty"`
+	// Structured location, flattened into the job JSON
+	Place
+	// Distance from ?near= in kilometres, included for radius searches only
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+	// Search details included for keyword (?q=) results only
+	SearchRank     *float64 `json:"search_rank,omitempty"`
+	TitleHighlight string   `json:"title_highlight,omitempty"`
+	Snippet        string   `json:"snippet,omitempty"`
+}
+
+// Salary represents a job's compensation range
+//
+// Fields:
+// - Min: Lower bound of the range (optional if Max is set)
+// - Max: Upper bound of the range (optional if Min is set)
+// - Currency: ISO 4217 currency code (e.g., "USD", "EUR", "INR")
+// - Period: Pay period - "hourly", "monthly" or "yearly"
+//
+// Database Columns: salary_min, salary_max, salary_currency, salary_period
+// - salary_min_annual / salary_max_annual are generated yearly equivalents used for filtering
+// - Legacy free-text salary values were migrated with best-effort parsing (e.g. "$120k-150k")
+type Salary struct {
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	Currency string   `json:"currency,omitempty"`
+	Period   string   `json:"period,omitempty"`
+}
+
+// Place describes where a job is performed
+//
+// Fields:
+// - WorkArrangement: "remote", "hybrid" or "onsite"
+// - Country: ISO 3166-1 alpha-2 code (e.g., "DE"); for remote jobs, where candidates must be based
+// - City: City name; geocoded with the offline gazetteer when coordinates are omitted
+// - Latitude/Longitude: Office coordinates used for radius search (?near=&radius_km=)
+// - UTCOffset: Office or team timezone as hours from UTC (e.g., 1, -5, 5.5)
+//
+// Database Columns: work_arrangement, country, city, latitude, longitude, utc_offset
+// - Embedded in Job, so its fields appear at the top level of the job JSON
+type Place struct {
+	WorkArrangement string   `json:"work_arrangement,omitempty"`
+	Country         string   `json:"country,omitempty"`
+	City            string   `json:"city,omitempty"`
+	Latitude        *float64 `json:"latitude,omitempty"`
+	Longitude       *float64 `json:"longitude,omitempty"`
+	UTCOffset       *float64 `json:"utc_offset,omitempty"`
 }
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// JobPlan represents a pricing tier for job postings from the job_plans table
+//
+// Fields:
+// - Slug: Stable identifier stored on jobs.plan (e.g., "basic", "featured", "urgent")
+// - Name: Human-readable label
+// - PriceWei: Minimum payment in wei, as a decimal string (amounts exceed int64)
+// - Prices: Price per payment method in that method's smallest unit, e.g. {"erc20": "5000000"} (5 USDC); only evm_native falls back to PriceWei, other methods without an entry can't pay for the plan
+// - DurationDays: Days a job on this plan stays listed once published (also added by each renewal)
+// - Perks: Human-readable list of what the plan includes (e.g., "Featured badge")
+// - Active: Whether new jobs can choose the plan; jobs already on an inactive plan keep it
+// - SortOrder: Display order in GET /plans
+// - CreatedAt / UpdatedAt: Audit timestamps
+//
+// API Usage:
+// - Listed by GET /plans (active only) and GET /admin/plans (all)
+// - Created and edited by admins via POST /admin/plans and PUT /admin/plans/:slug
+type JobPlan struct {
+	Slug         string            `json:"slug"`
+	Name         string            `json:"name"`
+	PriceWei     string            `json:"price_wei"`
+	Prices       map[string]string `json:"prices"`
+	DurationDays int               `json:"duration_days"`
+	Perks        []string          `json:"perks"`
+	Active       bool              `json:"active"`
+	SortOrder    int               `json:"sort_order"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
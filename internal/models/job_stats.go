@@ -0,0 +1,42 @@
+package models
+
+import "github.com/google/uuid"
+
+// JobStats holds aggregated performance counters for a job posting
+//
+// Fields:
+// - JobID: UUID of the job
+// - Views: Job detail views (GET /jobs/:id), excluding the poster's own
+// - UniqueViewers: Distinct signed-in users who viewed the job
+// - Saves: Users who currently have the job saved
+// - Applications: Applications received
+// - AvgMatchScore: Average AI match score of applications (nil if none were scored)
+//
+// API Usage:
+// - Returned per job by GET /me/jobs and as totals by GET /jobs/:id/stats
+type JobStats struct {
+	JobID         uuid.UUID `json:"job_id"`
+	Views         int       `json:"views"`
+	UniqueViewers int       `json:"unique_viewers"`
+	Saves         int       `json:"saves"`
+	Applications  int       `json:"applications"`
+	AvgMatchScore *float64  `json:"avg_match_score"`
+}
+
+// JobStatsDay holds one day of a job's activity
+//
+// Fields:
+// - Date: Day in YYYY-MM-DD (server time)
+// - Views / UniqueViewers: Detail views and distinct viewers that day
+// - Saves: Times the job was saved that day
+// - Applications: Applications received that day
+//
+// API Usage:
+// - Returned in the days series of GET /jobs/:id/stats, one entry per day including empty days
+type JobStatsDay struct {
+	Date          string `json:"date"`
+	Views         int    `json:"views"`
+	UniqueViewers int    `json:"unique_viewers"`
+	Saves         int    `json:"saves"`
+	Applications  int    `json:"applications"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// PaymentState describes the confirmation state of a job's posting payment
+//
+// Fields:
+// - Method: Payment method that verifies the payment (evm_native, erc20, ...)
+// - TxHash: Payment transaction hash (lowercased)
+// - ChainID: EVM chain the payment was made on
+// - Status: pending (waiting for confirmations), confirmed or failed
+// - Confirmations: Confirmations seen at the last check
+// - RequiredConfirmations: Confirmations needed before the job is published (PAYMENT_CONFIRMATIONS)
+// - FailureReason: Why the payment failed (empty unless Status is failed)
+// - CheckedAt: Last time the confirmation worker checked the transaction
+// - ConfirmedAt: When the payment reached the required confirmations
+//
+// API Usage:
+// - Returned as "payment" by GET /jobs/:id to the job's poster and company managers
+type PaymentState struct {
+	Method                string     `json:"method"`
+	TxHash                string     `json:"tx_hash"`
+	ChainID               int64      `json:"chain_id"`
+	Status                string     `json:"status"`
+	Confirmations         int        `json:"confirmations"`
+	RequiredConfirmations int        `json:"required_confirmations"`
+	FailureReason         string     `json:"failure_reason,omitempty"`
+	CheckedAt             *time.Time `json:"checked_at,omitempty"`
+	ConfirmedAt           *time.Time `json:"confirmed_at,omitempty"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoleAuditEntry is one grant or revoke of a platform role from the role_audit_log table
+//
+// Fields:
+// - ID: Unique identifier (UUID)
+// - Role: candidate, recruiter or admin
+// - Action: "grant" or "revoke"
+// - ActorID: Admin who made the change; nil for roles given by the system (registration, migration backfill)
+// - CreatedAt: When the change was made
+//
+// API Usage:
+// - Returned in the history of GET /admin/users/:id/roles
+type RoleAuditEntry struct {
+	ID        uuid.UUID  `json:"id"`
+	Role      string     `json:"role"`
+	Action    string     `json:"action"`
+	ActorID   *uuid.UUID `json:"actor_id"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// UserRoles is a user's current platform roles with their audit history
+//
+// Fields:
+// - UserID: The user
+// - Roles: Roles held now, least privileged first (same as the JWT "roles" claim)
+// - History: Grants and revokes, newest first
+//
+// API Usage:
+// - Returned by GET /admin/users/:id/roles
+type UserRoles struct {
+	UserID  uuid.UUID        `json:"user_id"`
+	Roles   []string         `json:"roles"`
+	History []RoleAuditEntry `json:"history"`
+}
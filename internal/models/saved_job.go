@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedJob represents a job bookmarked by a user
+//
+// Fields:
+// - JobID: UUID of the saved job
+// - UserID: UUID of the user who saved it
+// - Note: Optional private note, only visible to the user who saved the job
+// - CreatedAt: When the job was saved
+// - Job: The saved job posting (joined in for listing, not stored)
+//
+// Database Table: saved_jobs
+// - Primary key (user_id, job_id): a job can be saved once per user
+// - Rows are deleted with the job or the user (ON DELETE CASCADE)
+//
+// API Usage:
+// - Returned by POST /jobs/:id/save and GET /me/saved-jobs
+type SavedJob struct {
+	JobID     uuid.UUID `json:"job_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Job       *Job      `json:"job,omitempty"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedSearch represents a named job search a user wants alerts for
+//
+// Fields:
+// - ID: Unique identifier (UUID), primary key in database
+// - UserID: UUID of the user who owns the search
+// - Name: Display name (e.g., "Remote Go jobs")
+// - Skill: Skill filter, same as GET /jobs?skill=
+// - Location: Location substring filter, same as GET /jobs?location=
+// - Query: Keyword search, same as GET /jobs?q=
+// - CreatedAt: Creation timestamp
+//
+// Database Table: saved_searches
+// - At least one of skill, location or query is set
+// - Rows are deleted with the user (ON DELETE CASCADE)
+//
+// API Usage:
+// - Managed through /me/saved-searches
+// - New published jobs matching the filters produce AlertHits
+type SavedSearch struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	Skill     string    `json:"skill,omitempty"`
+	Location  string    `json:"location,omitempty"`
+	Query     string    `json:"q,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AlertHit records a new job matching one of a user's saved searches
+//
+// Fields:
+// - ID: Unique identifier (UUID), primary key in database
+// - SavedSearchID: UUID of the matching saved search
+// - UserID: UUID of the user owning the saved search
+// - JobID: UUID of the matching job
+// - CreatedAt: When the match was found
+// - ReadAt: When the user marked the alert as read (nil if unread)
+// - SearchName / JobTitle: Joined in for display and not stored
+//
+// Database Table: alert_hits
+// - Unique constraint on (saved_search_id, job_id): a job alerts once per search
+//
+// API Usage:
+// - Returned by GET /me/alerts (the in-app inbox)
+type AlertHit struct {
+	ID            uuid.UUID  `json:"id"`
+	SavedSearchID uuid.UUID  `json:"saved_search_id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	JobID         uuid.UUID  `json:"job_id"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ReadAt        *time.Time `json:"read_at,omitempty"`
+	// Display details included for listing
+	SearchName string `json:"search_name,omitempty"`
+	JobTitle   string `json:"job_title,omitempty"`
+}
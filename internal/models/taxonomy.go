@@ -0,0 +1,23 @@
+package models
+
+// Category represents a job category from the job_categories lookup table
+//
+// Fields:
+// - Slug: Stable identifier stored on jobs.category (e.g., "engineering")
+// - Name: Human-readable label (e.g., "Engineering")
+type Category struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// Taxonomy lists the allowed values for job classification fields
+//
+// API Usage:
+// - Returned by GET /jobs/taxonomy so clients don't hardcode the values
+// - Categories come from the job_categories table
+// - Employment types and seniority levels are fixed enums validated in services
+type Taxonomy struct {
+	Categories      []Category `json:"categories"`
+	EmploymentTypes []string   `json:"employment_types"`
+	SeniorityLevels []string   `json:"seniority_levels"`
+}
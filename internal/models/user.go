@@ -12,10 +12,13 @@ import (
 // - ID: Unique identifier (UUID), primary key in database
 // - Name: User's full name
 // - Email: Unique email address, used for login
+// - EmailVerified: The user confirmed Email via the emailed link (POST /auth/verify-email); needed to post jobs and posts
 // - Bio: Optional biography/description
 // - LinkedinURL: Optional LinkedIn profile URL
 // - Skills: Array of skill tags extracted from resume/bio
 // - WalletAddress: Optional Ethereum wallet address (for job posting)
+// - WalletVerified: Ownership of WalletAddress was proven with Sign-In with Ethereum (POST /auth/siwe/verify)
+// - Roles: Platform roles (candidate, recruiter, admin); only filled in by GET /me
 // - CreatedAt: Account creation timestamp
 //
 // Database Table: users
@@ -27,13 +30,17 @@ import (
 // - Returned by GET /me, GET /profile/:id, POST /auth/login, PUT /profile
 // - Skills array used for job matching algorithm
 // - WalletAddress indicates if user has blockchain payments enabled
+// - On-chain payment verification only accepts payments from a verified wallet
 type User struct {
-	ID            uuid.UUID `json:"id"`
-	Name          string    `json:"name"`
-	Email         string    `json:"email"`
-	Bio           string    `json:"bio,omitempty"`
-	LinkedinURL   string    `json:"linkedin_url,omitempty"`
-	Skills        []string  `json:"skills,omitempty"`
-	WalletAddress string    `json:"wallet_address,omitempty"`
-	CreatedAt     time.Time `json:"created_at,omitempty"`
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	EmailVerified  bool      `json:"email_verified"`
+	Bio            string    `json:"bio,omitempty"`
+	LinkedinURL    string    `json:"linkedin_url,omitempty"`
+	Skills         []string  `json:"skills,omitempty"`
+	WalletAddress  string    `json:"wallet_address,omitempty"`
+	WalletVerified bool      `json:"wallet_verified"`
+	Roles          []string  `json:"roles,omitempty"`
+	CreatedAt      time.Time `json:"created_at,omitempty"`
 }
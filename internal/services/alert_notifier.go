@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+)
+
+// AlertNotifier delivers saved-search alert hits outside the app.
+// Hits are always kept in the in-app inbox (alert_hits, GET /me/alerts);
+// the notifier is called once per new hit after it has been stored.
+type AlertNotifier interface {
+	Notify(ctx context.Context, email string, hit models.AlertHit) error
+}
+
+var (
+	alertNotifierMu sync.RWMutex
+	alertNotifier   AlertNotifier = LogNotifier{}
+)
+
+// SetAlertNotifier replaces the notifier used by the saved-search matcher.
+// Passing nil keeps alerts in-app only.
+//
+// Usage: Called once from main based on ALERT_NOTIFIER
+func SetAlertNotifier(n AlertNotifier) {
+	alertNotifierMu.Lock()
+	defer alertNotifierMu.Unlock()
+	alertNotifier = n
+}
+
+func currentAlertNotifier() AlertNotifier {
+	alertNotifierMu.RLock()
+	defer alertNotifierMu.RUnlock()
+	return alertNotifier
+}
+
+// LogNotifier writes alert hits to the server log. Useful in development.
+type LogNotifier struct{}
+
+// Notify logs the hit.
+func (LogNotifier) Notify(_ context.Context, email string, hit models.AlertHit) error {
+	log.Printf("job alert for %s: %q matched saved search %q (job %s)", email, hit.JobTitle, hit.SearchName, hit.JobID)
+	return nil
+}
+
+// SMTPNotifier emails alert hits through a plain SMTP server (see SMTPMailer).
+//
+// Fields:
+// - Addr: host:port of the SMTP server (a local fake server such as MailHog works)
+// - Username / Password: PLAIN auth credentials; auth is skipped when Username is empty
+// - From: Sender address
+// - JobURL: Base URL the job ID is appended to in the email body (e.g., https://app/jobs/)
+type SMTPNotifier struct {
+	Addr     string
+	Username string
+	Password string
+	From     string
+	JobURL   string
+}
+
+// Notify sends a short plain-text email about the hit.
+func (n SMTPNotifier) Notify(ctx context.Context, email string, hit models.AlertHit) error {
+	m := SMTPMailer{Addr: n.Addr, Username: n.Username, Password: n.Password, From: n.From}
+	return m.Send(ctx, Email{
+		To:      email,
+		Subject: fmt.Sprintf("New job for \"%s\": %s", hit.SearchName, hit.JobTitle),
+		Body:    fmt.Sprintf("A new job matches your saved search \"%s\":\n\n%s\n%s%s\n", hit.SearchName, hit.JobTitle, n.JobURL, hit.JobID),
+	})
+}
+
+// headerSafe strips line breaks so user-provided text can't inject mail headers.
+func headerSafe(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
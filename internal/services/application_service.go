@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAlreadyApplied = errors.New("you have already applied to this job")
+	ErrOwnJob         = errors.New("cannot apply to your own job")
+	ErrNotJobOwner    = errors.New("only the job poster or its company's recruiters can perform this action")
+	ErrJobClosed      = errors.New("job is no longer accepting applications")
+)
+
+// ApplyToJob submits a candidate's application to a job posting
+//
+// Process:
+// 1. Parse user ID and load the job (ErrJobNotFound if missing or not visible to the candidate, see GetVisibleJob)
+// 2. Reject applications to the candidate's own posting or to jobs that aren't published
+// 3. Reject duplicate applications (one per candidate per job)
+// 4. Snapshot the AI match score between candidate skills and job description
+// 5. Insert application record
+//
+// Parameters:
+// - ctx: Context for the AI match score call
+// - jobIDStr: UUID string of the job
+// - userIDStr: UUID string of the applying candidate
+// - coverNote: Optional cover note
+// - resumeURL: Optional resume reference
+//
+// Returns:
+// - *models.Application on success
+// - ErrJobNotFound, ErrOwnJob, ErrJobClosed or ErrAlreadyApplied on validation failure
+// - Other error if database operation fails
+//
+// Note: If the AI service fails, the application is still created without a score.
+//
+// Usage: Called by POST /jobs/:id/apply endpoint
+func ApplyToJob(ctx context.Context, jobIDStr, userIDStr, coverNote, resumeURL string) (*models.Application, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := GetVisibleJob(jobIDStr, userIDStr)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserID == userID {
+		return nil, ErrOwnJob
+	}
+	if job.Status != JobStatusPublished || (job.ExpiresAt != nil && job.ExpiresAt.Before(time.Now())) {
+		return nil, ErrJobClosed
+	}
+
+	var exists bool
+	err = db.Pool.QueryRow(context.Background(),
+		"SELECT EXISTS(SELECT 1 FROM applications WHERE job_id=$1 AND user_id=$2)",
+		job.ID, userID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrAlreadyApplied
+	}
+
+	user, err := GetUserByID(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var matchScore *int
+	if score, err := ComputeMatchScore(ctx, user.Skills, job.Description); err == nil {
+		matchScore = &score
+	} else {
+		log.Println("match score snapshot failed:", err)
+	}
+
+	app := &models.Application{
+		ID:         uuid.New(),
+		JobID:      job.ID,
+		UserID:     userID,
+		CoverNote:  coverNote,
+		ResumeURL:  resumeURL,
+		MatchScore: matchScore,
+		CreatedAt:  time.Now(),
+		JobTitle:   job.Title,
+	}
+
+	// ON CONFLICT guards against two concurrent applies slipping past the check above
+	tag, err := db.Pool.Exec(context.Background(),
+		`INSERT INTO applications (id, job_id, user_id, cover_note, resume_url, match_score, created_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7)
+		 ON CONFLICT (job_id, user_id) DO NOTHING`,
+		app.ID, app.JobID, app.UserID, app.CoverNote, app.ResumeURL, app.MatchScore, app.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrAlreadyApplied
+	}
+
+	return app, nil
+}
+
+// ListUserApplications retrieves all applications submitted by a candidate.
+//
+// Parameters:
+// - userID: UUID of the candidate
+//
+// Returns:
+// - applications: Slice of Application objects with job titles, newest first
+// - error: if database query fails
+//
+// Usage: Called by GET /me/applications endpoint
+func ListUserApplications(userID string) ([]models.Application, error) {
+	query := `
+		SELECT a.id, a.job_id, a.user_id, a.cover_note, a.resume_url, a.match_score, a.created_at, j.title
+		FROM applications a
+		JOIN jobs j ON a.job_id = j.id
+		WHERE a.user_id = $1
+		ORDER BY a.created_at DESC
+	`
+
+	rows, err := db.Pool.Query(context.Background(), query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apps []models.Application
+	for rows.Next() {
+		var (
+			a                    models.Application
+			coverNote, resumeURL *string
+		)
+		if err := rows.Scan(&a.ID, &a.JobID, &a.UserID, &coverNote, &resumeURL, &a.MatchScore, &a.CreatedAt, &a.JobTitle); err != nil {
+			return nil, err
+		}
+		a.CoverNote = safeStr(coverNote)
+		a.ResumeURL = safeStr(resumeURL)
+		apps = append(apps, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
+
+// ListJobApplications retrieves all applications for a job.
+// Only the poster and members of the job's company may list them.
+//
+// Parameters:
+// - jobIDStr: UUID string of the job
+// - requesterID: UUID string of the caller (the poster or a company member)
+//
+// Returns:
+// - applications: Slice of Application objects with applicant details, best match first
+// - ErrJobNotFound if job doesn't exist, ErrNotJobOwner if caller may not view it
+// - Other error if database query fails
+//
+// Usage: Called by GET /jobs/:id/applications endpoint
+func ListJobApplications(jobIDStr, requesterID string) ([]models.Application, error) {
+	job, err := getViewableJob(jobIDStr, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT a.id, a.job_id, a.user_id, a.cover_note, a.resume_url, a.match_score, a.created_at, u.name, u.email
+		FROM applications a
+		JOIN users u ON a.user_id = u.id
+		WHERE a.job_id = $1
+		ORDER BY a.match_score DESC NULLS LAST, a.created_at ASC
+	`
+
+	rows, err := db.Pool.Query(context.Background(), query, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apps []models.Application
+	for rows.Next() {
+		var (
+			a                    models.Application
+			coverNote, resumeURL *string
+		)
+		if err := rows.Scan(&a.ID, &a.JobID, &a.UserID, &coverNote, &resumeURL, &a.MatchScore, &a.CreatedAt, &a.ApplicantName, &a.ApplicantEmail); err != nil {
+			return nil, err
+		}
+		a.CoverNote = safeStr(coverNote)
+		a.ResumeURL = safeStr(resumeURL)
+		a.JobTitle = job.Title
+		apps = append(apps, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
@@ -19,7 +19,9 @@ import (
 // 1. Check if email already registered (prevent duplicates)
 // 2. Hash password using bcrypt (cost 10)
 // 3. Generate UUID for new user
-// 4. Insert user record into PostgreSQL
+// 4. Insert user record into PostgreSQL together with the candidate role (audited, no actor)
+//
+// Recruiter and admin are never self-service: only an admin grants them.
 //
 // Parameters:
 // - name: User's full name
@@ -49,7 +51,13 @@ func RegisterUser(name, email, password string) (string, error) {
 
 	id := uuid.New()
 
-	_, err = db.Pool.Exec(context.Background(),
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(),
 		`INSERT INTO users (id, name, email, password_hash, created_at)
 		 VALUES ($1,$2,$3,$4,$5)`,
 		id, name, email, hash, time.Now(),
@@ -58,6 +66,14 @@ func RegisterUser(name, email, password string) (string, error) {
 		return "", err
 	}
 
+	// Every account can apply to jobs
+	if err := grantRole(context.Background(), tx, id, RoleCandidate, nil); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(context.Background()); err != nil {
+		return "", err
+	}
 	return id.String(), nil
 }
 
@@ -102,7 +118,7 @@ func LoginUser(email, password string) (string, error) {
 // Process:
 // 1. Query users table by ID
 // 2. Unmarshal skills JSON array
-// 3. Handle nullable fields (bio, linkedin_url, wallet_address)
+// 3. Handle nullable fields (bio, linkedin_url, wallet_address) and the email_verified / wallet_verified flags
 // 4. Return fully populated User model
 //
 // Parameters:
@@ -118,17 +134,19 @@ func GetUserByID(userID string) (*models.User, error) {
 		id        uuid.UUID
 		name      string
 		email     string
+		emailOK   bool
 		bio       *string
 		linkedin  *string
 		skillsRaw []byte
 		wallet    *string
+		verified  bool
 		createdAt time.Time
 	)
 
 	err := db.Pool.QueryRow(context.Background(),
-		`SELECT id, name, email, bio, linkedin_url, skills, wallet_address, created_at
+		`SELECT id, name, email, email_verified, bio, linkedin_url, skills, wallet_address, wallet_verified, created_at
 		 FROM users WHERE id=$1`, userID,
-	).Scan(&id, &name, &email, &bio, &linkedin, &skillsRaw, &wallet, &createdAt)
+	).Scan(&id, &name, &email, &emailOK, &bio, &linkedin, &skillsRaw, &wallet, &verified, &createdAt)
 
 	if err != nil {
 		return nil, err
@@ -142,7 +160,7 @@ func GetUserByID(userID string) (*models.User, error) {
 		// - "name": string - User's full name
 		// - "bio": string - User biography/description
 		// - "linkedin_url": string - LinkedIn profile URL
-		// - "wallet_address": string - Ethereum wallet address (Sepolia)
+		// - "wallet_address": string - Ethereum wallet address (Sepolia); changing it clears wallet_verified
 		// - "skills": []string - Array of skill tags
 		//
 		// Process:
@@ -172,14 +190,16 @@ func GetUserByID(userID string) (*models.User, error) {
 	}
 
 	u := &models.User{
-		ID:            id,
-		Name:          name,
-		Email:         email,
-		Bio:           safeStr(bio),
-		LinkedinURL:   safeStr(linkedin),
-		Skills:        skills,
-		WalletAddress: safeStr(wallet),
-		CreatedAt:     createdAt,
+		ID:             id,
+		Name:           name,
+		Email:          email,
+		EmailVerified:  emailOK,
+		Bio:            safeStr(bio),
+		LinkedinURL:    safeStr(linkedin),
+		Skills:         skills,
+		WalletAddress:  safeStr(wallet),
+		WalletVerified: verified,
+		CreatedAt:      createdAt,
 	}
 	return u, nil
 }
@@ -207,7 +227,9 @@ func UpdateUser(userID string, updates map[string]interface{}) error {
 		argIdx++
 	}
 	if v, ok := updates["wallet_address"].(string); ok {
-		setClauses = append(setClauses, `wallet_address = $`+itoa(argIdx))
+		// A different address has to be proven again via POST /auth/siwe/verify
+		setClauses = append(setClauses, `wallet_address = $`+itoa(argIdx),
+			`wallet_verified = (wallet_verified AND lower(COALESCE(wallet_address, '')) = lower($`+itoa(argIdx)+`))`)
 		args = append(args, v)
 		argIdx++
 	}
@@ -238,6 +260,14 @@ func safeStr(ptr *string) string {
 	}
 	return *ptr
 }
+
+// nullStr maps "" to SQL NULL for optional text columns.
+func nullStr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
 func itoa(i int) string { return fmt.Sprintf("%d", i) }
 func join(arr []string, sep string) string {
 	out := ""
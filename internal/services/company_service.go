@@ -0,0 +1,462 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrCompanyNotFound    = errors.New("company not found")
+	ErrInvalidCompany     = errors.New("company name is required")
+	ErrNotCompanyMember   = errors.New("you are not a member of this company")
+	ErrCompanyRole        = errors.New("your company role does not allow this action")
+	ErrInvalidCompanyRole = errors.New("role must be owner, recruiter or viewer")
+	ErrMemberNotFound     = errors.New("company member not found")
+	ErrMemberExists       = errors.New("user is already a member of this company")
+	ErrMemberUserNotFound = errors.New("no user with that email")
+	ErrLastCompanyOwner   = errors.New("a company must keep at least one owner")
+)
+
+// Company membership roles.
+const (
+	CompanyRoleOwner     = "owner"
+	CompanyRoleRecruiter = "recruiter"
+	CompanyRoleViewer    = "viewer"
+)
+
+// CompanyRoles lists all membership roles, most privileged first.
+var CompanyRoles = []string{CompanyRoleOwner, CompanyRoleRecruiter, CompanyRoleViewer}
+
+// companyManagerRoles may post and manage company jobs and their applications.
+var companyManagerRoles = []string{CompanyRoleOwner, CompanyRoleRecruiter}
+
+// IsValidCompanyRole reports whether role is one of CompanyRoles.
+func IsValidCompanyRole(role string) bool {
+	return contains(CompanyRoles, role)
+}
+
+// companyRole returns userID's role in a company.
+// Returns ErrCompanyNotFound if the company doesn't exist, ErrNotCompanyMember if the user isn't a member.
+func companyRole(companyID, userID string) (string, error) {
+	var (
+		exists bool
+		role   *string
+	)
+	err := db.Pool.QueryRow(context.Background(),
+		`SELECT EXISTS(SELECT 1 FROM companies WHERE id=$1),
+		        (SELECT role FROM company_members WHERE company_id=$1 AND user_id=$2)`,
+		companyID, userID,
+	).Scan(&exists, &role)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", ErrCompanyNotFound
+	}
+	if role == nil {
+		return "", ErrNotCompanyMember
+	}
+	return *role, nil
+}
+
+// requireCompanyRole checks that userID holds one of roles in the company.
+// Returns ErrCompanyNotFound, ErrNotCompanyMember or ErrCompanyRole on failure.
+func requireCompanyRole(companyID, userID string, roles ...string) error {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return ErrCompanyNotFound
+	}
+	role, err := companyRole(companyID, userID)
+	if err != nil {
+		return err
+	}
+	if !contains(roles, role) {
+		return ErrCompanyRole
+	}
+	return nil
+}
+
+// CreateCompany creates a company and makes the creator its first owner
+//
+// Parameters:
+// - userIDStr: UUID string of the creator
+// - c: Company profile (Name required; ID and CreatedAt are assigned)
+//
+// Returns:
+// - *models.Company with Role "owner"
+// - ErrInvalidCompany if the name is empty
+// - Other error if database operation fails
+//
+// Usage: Called by POST /companies endpoint
+func CreateCompany(userIDStr string, c models.Company) (*models.Company, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return nil, ErrInvalidCompany
+	}
+	c.ID = uuid.New()
+	c.CreatedAt = time.Now()
+	c.Role = CompanyRoleOwner
+
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(),
+		`INSERT INTO companies (id, name, logo_url, website, description, created_at)
+		 VALUES ($1,$2,$3,$4,$5,$6)`,
+		c.ID, c.Name, nullStr(c.LogoURL), nullStr(c.Website), nullStr(c.Description), c.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(context.Background(),
+		`INSERT INTO company_members (company_id, user_id, role, created_at) VALUES ($1,$2,$3,$4)`,
+		c.ID, userID, CompanyRoleOwner, c.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(context.Background()); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetCompany retrieves a company profile by ID.
+//
+// Returns ErrCompanyNotFound if it doesn't exist.
+//
+// Usage: Called by GET /companies/:id endpoint
+func GetCompany(companyID string) (*models.Company, error) {
+	id, err := uuid.Parse(companyID)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+
+	var (
+		c                             models.Company
+		logoURL, website, description *string
+	)
+	err = db.Pool.QueryRow(context.Background(),
+		`SELECT id, name, logo_url, website, description, created_at FROM companies WHERE id=$1`, id,
+	).Scan(&c.ID, &c.Name, &logoURL, &website, &description, &c.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, ErrCompanyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.LogoURL = safeStr(logoURL)
+	c.Website = safeStr(website)
+	c.Description = safeStr(description)
+	return &c, nil
+}
+
+// UpdateCompany modifies a company profile. Only owners may edit it.
+//
+// Supported fields in updates map:
+// - "name": string - Company name (must not be empty)
+// - "logo_url", "website", "description": string - Profile fields ("" clears them)
+//
+// Returns:
+// - *models.Company with the updated profile
+// - ErrInvalidCompany, ErrCompanyNotFound, ErrNotCompanyMember or ErrCompanyRole on failure
+//
+// Usage: Called by PUT /companies/:id endpoint
+func UpdateCompany(companyID, userID string, updates map[string]interface{}) (*models.Company, error) {
+	if err := requireCompanyRole(companyID, userID, CompanyRoleOwner); err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{}
+	setClauses := []string{}
+	argIdx := 1
+
+	if v, ok := updates["name"].(string); ok {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil, ErrInvalidCompany
+		}
+		setClauses = append(setClauses, `name = $`+itoa(argIdx))
+		args = append(args, v)
+		argIdx++
+	}
+	for _, field := range []string{"logo_url", "website", "description"} {
+		if v, ok := updates[field].(string); ok {
+			setClauses = append(setClauses, field+` = $`+itoa(argIdx))
+			args = append(args, nullStr(v))
+			argIdx++
+		}
+	}
+
+	if len(setClauses) > 0 {
+		args = append(args, companyID)
+		query := `UPDATE companies SET ` + join(setClauses, ", ") + ` WHERE id = $` + itoa(argIdx)
+		if _, err := db.Pool.Exec(context.Background(), query, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	return GetCompany(companyID)
+}
+
+// ListUserCompanies retrieves the companies a user belongs to, with the user's role in each.
+//
+// Usage: Called by GET /me/companies endpoint
+func ListUserCompanies(userID string) ([]models.Company, error) {
+	rows, err := db.Pool.Query(context.Background(),
+		`SELECT c.id, c.name, c.logo_url, c.website, c.description, c.created_at, m.role
+		 FROM company_members m
+		 JOIN companies c ON c.id = m.company_id
+		 WHERE m.user_id = $1
+		 ORDER BY c.name`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var companies []models.Company
+	for rows.Next() {
+		var (
+			c                             models.Company
+			logoURL, website, description *string
+		)
+		if err := rows.Scan(&c.ID, &c.Name, &logoURL, &website, &description, &c.CreatedAt, &c.Role); err != nil {
+			return nil, err
+		}
+		c.LogoURL = safeStr(logoURL)
+		c.Website = safeStr(website)
+		c.Description = safeStr(description)
+		companies = append(companies, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return companies, nil
+}
+
+// ListCompanyMembers retrieves a company's members. Any member may list them.
+//
+// Returns:
+// - members: Slice of CompanyMember objects with name and email, owners first
+// - ErrCompanyNotFound or ErrNotCompanyMember on failure
+//
+// Usage: Called by GET /companies/:id/members endpoint
+func ListCompanyMembers(companyID, requesterID string) ([]models.CompanyMember, error) {
+	if err := requireCompanyRole(companyID, requesterID, CompanyRoles...); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Pool.Query(context.Background(),
+		`SELECT m.company_id, m.user_id, m.role, m.created_at, u.name, u.email
+		 FROM company_members m
+		 JOIN users u ON u.id = m.user_id
+		 WHERE m.company_id = $1
+		 ORDER BY array_position($2::text[], m.role), u.name`,
+		companyID, CompanyRoles,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []models.CompanyMember
+	for rows.Next() {
+		var m models.CompanyMember
+		if err := rows.Scan(&m.CompanyID, &m.UserID, &m.Role, &m.CreatedAt, &m.Name, &m.Email); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// AddCompanyMember adds a registered user to a company. Only owners may add members.
+// Membership never changes platform roles: company owners and recruiters post the company's jobs
+// through their company role (see CreateJob).
+//
+// Parameters:
+// - companyID: UUID string of the company
+// - requesterID: UUID string of the caller (must be an owner)
+// - email: Email of the user to add
+// - role: owner, recruiter or viewer
+//
+// Returns:
+// - *models.CompanyMember on success
+// - ErrInvalidCompanyRole, ErrMemberUserNotFound or ErrMemberExists on validation failure
+// - ErrCompanyNotFound, ErrNotCompanyMember or ErrCompanyRole if the caller may not add members
+//
+// Usage: Called by POST /companies/:id/members endpoint
+func AddCompanyMember(companyID, requesterID, email, role string) (*models.CompanyMember, error) {
+	if !IsValidCompanyRole(role) {
+		return nil, ErrInvalidCompanyRole
+	}
+	if err := requireCompanyRole(companyID, requesterID, CompanyRoleOwner); err != nil {
+		return nil, err
+	}
+
+	m := &models.CompanyMember{Role: role, CreatedAt: time.Now(), Email: email}
+	m.CompanyID, _ = uuid.Parse(companyID)
+
+	err := db.Pool.QueryRow(context.Background(),
+		"SELECT id, name FROM users WHERE email=$1", email,
+	).Scan(&m.UserID, &m.Name)
+	if err == pgx.ErrNoRows {
+		return nil, ErrMemberUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := db.Pool.Exec(context.Background(),
+		`INSERT INTO company_members (company_id, user_id, role, created_at)
+		 VALUES ($1,$2,$3,$4)
+		 ON CONFLICT (company_id, user_id) DO NOTHING`,
+		m.CompanyID, m.UserID, m.Role, m.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrMemberExists
+	}
+
+	return m, nil
+}
+
+// UpdateCompanyMemberRole changes a member's role. Only owners may change roles,
+// and the last owner cannot be demoted.
+//
+// Returns:
+// - ErrInvalidCompanyRole, ErrMemberNotFound or ErrLastCompanyOwner on validation failure
+// - ErrCompanyNotFound, ErrNotCompanyMember or ErrCompanyRole if the caller may not change roles
+//
+// Usage: Called by PUT /companies/:id/members/:user_id endpoint
+func UpdateCompanyMemberRole(companyID, requesterID, memberID, role string) error {
+	if !IsValidCompanyRole(role) {
+		return ErrInvalidCompanyRole
+	}
+	if err := requireCompanyRole(companyID, requesterID, CompanyRoleOwner); err != nil {
+		return err
+	}
+
+	return changeCompanyMember(companyID, memberID, func(tx pgx.Tx) (int64, error) {
+		tag, err := tx.Exec(context.Background(),
+			"UPDATE company_members SET role=$1 WHERE company_id=$2 AND user_id=$3",
+			role, companyID, memberID)
+		return tag.RowsAffected(), err
+	})
+}
+
+// RemoveCompanyMember removes a member from a company. Owners may remove anyone;
+// any member may remove themselves (leave). The last owner cannot be removed.
+//
+// Returns:
+// - ErrMemberNotFound or ErrLastCompanyOwner on validation failure
+// - ErrCompanyNotFound, ErrNotCompanyMember or ErrCompanyRole if the caller may not remove the member
+//
+// Usage: Called by DELETE /companies/:id/members/:user_id endpoint
+func RemoveCompanyMember(companyID, requesterID, memberID string) error {
+	roles := []string{CompanyRoleOwner}
+	if requesterID == memberID {
+		roles = CompanyRoles
+	}
+	if err := requireCompanyRole(companyID, requesterID, roles...); err != nil {
+		return err
+	}
+
+	return changeCompanyMember(companyID, memberID, func(tx pgx.Tx) (int64, error) {
+		tag, err := tx.Exec(context.Background(),
+			"DELETE FROM company_members WHERE company_id=$1 AND user_id=$2",
+			companyID, memberID)
+		return tag.RowsAffected(), err
+	})
+}
+
+// changeCompanyMember runs a membership change in a transaction and rolls it back
+// if the member doesn't exist or the company would be left without an owner.
+func changeCompanyMember(companyID, memberID string, change func(tx pgx.Tx) (int64, error)) error {
+	if _, err := uuid.Parse(memberID); err != nil {
+		return ErrMemberNotFound
+	}
+
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	// Lock the company's owner rows so concurrent demotions can't both pass the check
+	_, err = tx.Exec(context.Background(),
+		"SELECT 1 FROM company_members WHERE company_id=$1 AND role=$2 FOR UPDATE",
+		companyID, CompanyRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	affected, err := change(tx)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrMemberNotFound
+	}
+
+	var owners int
+	err = tx.QueryRow(context.Background(),
+		"SELECT COUNT(*) FROM company_members WHERE company_id=$1 AND role=$2",
+		companyID, CompanyRoleOwner,
+	).Scan(&owners)
+	if err != nil {
+		return err
+	}
+	if owners == 0 {
+		return ErrLastCompanyOwner
+	}
+
+	return tx.Commit(context.Background())
+}
+
+// ListCompanyJobs retrieves a page of a company's jobs in any status. Any member may list them.
+//
+// Parameters:
+// - companyID: UUID string of the company
+// - requesterID: UUID string of the caller (must be a member)
+// - status: Optional status filter ("" for all)
+// - limit, cursor: Keyset pagination as in ListJobs
+//
+// Returns:
+// - jobs and next cursor as in ListJobsWithFilters
+// - ErrCompanyNotFound or ErrNotCompanyMember if the caller may not view them
+//
+// Usage: Called by GET /companies/:id/jobs endpoint
+func ListCompanyJobs(companyID, requesterID, status string, limit int, cursor string) ([]*models.Job, string, error) {
+	if err := requireCompanyRole(companyID, requesterID, CompanyRoles...); err != nil {
+		return nil, "", err
+	}
+	return ListJobsWithFilters(JobFilter{CompanyID: companyID, Status: status, Limit: limit, Cursor: cursor})
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNoCredits             = errors.New("no posting credits left for this plan: send payment_tx_hash or buy credits (POST /me/credits)")
+	ErrInvalidCreditQuantity = errors.New("quantity must be between 1 and 100")
+)
+
+// MaxCreditPurchase caps how many credits one payment can buy.
+const MaxCreditPurchase = 100
+
+// creditHistoryLimit caps the ledger entries returned by GetCredits.
+const creditHistoryLimit = 100
+
+// Posting credit ledger reasons (posting_credits.reason).
+const (
+	CreditReasonPurchase = "purchase"
+	CreditReasonJobPost  = "job_post"
+)
+
+// creditAccount identifies whose credits are used: the company's when CompanyID is set,
+// otherwise the user's own. UserID is always the acting user.
+type creditAccount struct {
+	UserID    uuid.UUID
+	CompanyID *uuid.UUID
+}
+
+// owner returns the posting_credits column and ID that select the account's entries.
+func (a creditAccount) owner() (string, uuid.UUID) {
+	if a.CompanyID != nil {
+		return "company_id", *a.CompanyID
+	}
+	return "user_id", a.UserID
+}
+
+// resolveCreditAccount parses the caller and optional company, requiring one of roles in the company.
+func resolveCreditAccount(userIDStr, companyIDStr string, roles ...string) (creditAccount, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return creditAccount{}, err
+	}
+	a := creditAccount{UserID: userID}
+	if companyIDStr != "" {
+		if err := requireCompanyRole(companyIDStr, userIDStr, roles...); err != nil {
+			return creditAccount{}, err
+		}
+		cid, _ := uuid.Parse(companyIDStr)
+		a.CompanyID = &cid
+	}
+	return a, nil
+}
+
+// insertCreditEntry appends a grant (delta > 0) or debit (delta < 0) to the ledger inside tx.
+func insertCreditEntry(ctx context.Context, tx pgx.Tx, a creditAccount, plan string, delta int, reason string, jobID, paymentID *uuid.UUID) error {
+	var userID *uuid.UUID
+	if a.CompanyID == nil {
+		userID = &a.UserID
+	}
+	_, err := tx.Exec(ctx,
+		`INSERT INTO posting_credits (id, user_id, company_id, plan, delta, reason, job_id, payment_id, actor_id, created_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,NOW())`,
+		uuid.New(), userID, a.CompanyID, plan, delta, reason, jobID, paymentID, a.UserID)
+	return err
+}
+
+// consumeCredit spends one credit for plan on jobID inside tx.
+//
+// The account's user or company row is locked for the rest of tx, so concurrent
+// postings can't spend the same credit twice.
+//
+// Returns ErrNoCredits if the account has no credit left for the plan.
+func consumeCredit(ctx context.Context, tx pgx.Tx, a creditAccount, plan string, jobID uuid.UUID) error {
+	table, id := "users", a.UserID
+	if a.CompanyID != nil {
+		table, id = "companies", *a.CompanyID
+	}
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM `+table+` WHERE id = $1 FOR UPDATE`, id); err != nil {
+		return err
+	}
+
+	col, owner := a.owner()
+	var balance int
+	err := tx.QueryRow(ctx,
+		`SELECT COALESCE(SUM(delta), 0) FROM posting_credits WHERE `+col+` = $1 AND plan = $2`,
+		owner, plan,
+	).Scan(&balance)
+	if err != nil {
+		return err
+	}
+	if balance < 1 {
+		return ErrNoCredits
+	}
+
+	return insertCreditEntry(ctx, tx, a, plan, -1, CreditReasonJobPost, &jobID, nil)
+}
+
+// PurchaseCreditsInput holds the fields accepted when buying posting credits.
+//
+// Fields:
+// - UserID: UUID string of the buyer
+// - CompanyID: Optional company the credits are for (buyer must be an owner or recruiter)
+// - Plan: Pricing plan the credits post on (default: DefaultJobPlan)
+// - Quantity: Number of credits, 1..MaxCreditPurchase
+// - PaymentTxHash / PaymentMethod: Payment reference and provider, as for CreateJob
+// - ChainID: Chain the payment was made on
+// - Confirmations: Confirmations the payment needs
+type PurchaseCreditsInput struct {
+	UserID        string
+	CompanyID     string
+	Plan          string
+	Quantity      int
+	PaymentTxHash string
+	PaymentMethod string
+	ChainID       int64
+	Confirmations int
+}
+
+// PurchaseCredits buys posting credits with one payment
+//
+// Process:
+// 1. Resolve the account (the buyer, or a company they own or recruit for)
+// 2. Require an active plan and a quantity of 1..MaxCreditPurchase
+// 3. Verify the payment covers quantity × the plan's price for the payment method
+// 4. Record the payment (purpose credit_purchase) and the credit grant in one transaction
+//
+// Unlike job postings, purchases aren't parked while the payment confirms: an unconfirmed
+// payment returns ErrPaymentPending and the same hash can be sent again later.
+// Purchases always need a registered verifier: with evm_native unverified (ETH_RPC_URL unset)
+// they return ErrPaymentUnavailable instead of accepting a well-formed hash.
+//
+// Returns:
+// - *models.Credits: The account after the purchase
+// - ErrInvalidCreditQuantity, ErrPlanNotFound, ErrInvalidPlan (wrapped), ErrPaymentRequired
+// - Company errors (ErrCompanyNotFound, ErrNotCompanyMember, ErrCompanyRole)
+// - Payment errors as for CreateJob, including ErrPaymentPending and *PaymentConsumedError
+// - ErrPaymentUnavailable (wrapped) if the payment method has no verifier
+//
+// Usage: Called by POST /me/credits endpoint
+func PurchaseCredits(in PurchaseCreditsInput) (*models.Credits, error) {
+	a, err := resolveCreditAccount(in.UserID, in.CompanyID, companyManagerRoles...)
+	if err != nil {
+		return nil, err
+	}
+	if in.Quantity < 1 || in.Quantity > MaxCreditPurchase {
+		return nil, ErrInvalidCreditQuantity
+	}
+	if in.PaymentTxHash == "" {
+		return nil, ErrPaymentRequired
+	}
+
+	planSlug := in.Plan
+	if planSlug == "" {
+		planSlug = DefaultJobPlan
+	}
+	plan, err := GetJobPlan(planSlug)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.Active {
+		return nil, fmt.Errorf("%w: plan %q is no longer available", ErrInvalidPlan, plan.Slug)
+	}
+
+	method, verifier, err := paymentVerifierFor(in.PaymentMethod)
+	if err != nil {
+		return nil, err
+	}
+	// Unverified evm_native (no ETH_RPC_URL) only checks the hash format; that must not mint credits
+	if verifier == nil {
+		return nil, fmt.Errorf("%w: %s payments can't be verified, so credits can't be bought with them", ErrPaymentUnavailable, method)
+	}
+	if err := validatePaymentReference(verifier, in.PaymentTxHash); err != nil {
+		return nil, err
+	}
+	if err := checkPaymentUnused(context.Background(), in.ChainID, in.PaymentTxHash); err != nil {
+		return nil, err
+	}
+	unitPrice, err := planPrice(plan, method)
+	if err != nil {
+		return nil, err
+	}
+	price := new(big.Int).Mul(unitPrice, big.NewInt(int64(in.Quantity)))
+	claim, err := newPaymentClaim(in.PaymentTxHash, in.UserID, price)
+	if err != nil {
+		return nil, err
+	}
+	confirmations, err := verifyPayment(context.Background(), method, claim, in.Confirmations)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+
+	paymentID, err := insertPayment(context.Background(), tx, in.ChainID, method, claim, nil, a.UserID,
+		PaymentPurposeCredits, PaymentStatusConfirmed, "", confirmations)
+	if err != nil {
+		return nil, err
+	}
+	if err := insertCreditEntry(context.Background(), tx, a, plan.Slug, in.Quantity, CreditReasonPurchase, nil, &paymentID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return GetCredits(in.UserID, in.CompanyID)
+}
+
+// GetCredits retrieves a posting credit account with its balances and latest ledger entries
+//
+// Parameters:
+// - userIDStr: UUID string of the caller
+// - companyIDStr: Optional company; any member may view its credits
+//
+// Returns:
+// - *models.Credits with balances per plan and up to 100 ledger entries, newest first
+// - Company errors (ErrCompanyNotFound, ErrNotCompanyMember) or database error
+//
+// Usage: Called by GET /me/credits endpoint
+func GetCredits(userIDStr, companyIDStr string) (*models.Credits, error) {
+	a, err := resolveCreditAccount(userIDStr, companyIDStr, CompanyRoles...)
+	if err != nil {
+		return nil, err
+	}
+	col, owner := a.owner()
+	credits := &models.Credits{CompanyID: a.CompanyID, Balances: []models.CreditBalance{}, History: []models.CreditEntry{}}
+
+	rows, err := db.Pool.Query(context.Background(),
+		`SELECT plan, SUM(delta) FROM posting_credits WHERE `+col+` = $1
+		 GROUP BY plan HAVING SUM(delta) <> 0 ORDER BY plan`,
+		owner)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var b models.CreditBalance
+		if err := rows.Scan(&b.Plan, &b.Credits); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		credits.Balances = append(credits.Balances, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Pool.Query(context.Background(),
+		`SELECT c.id, c.plan, c.delta, c.reason, c.job_id, p.tx_hash, c.actor_id, c.created_at
+		 FROM posting_credits c
+		 LEFT JOIN payments p ON p.id = c.payment_id
+		 WHERE c.`+col+` = $1
+		 ORDER BY c.created_at DESC, c.id DESC
+		 LIMIT $2`,
+		owner, creditHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			e      models.CreditEntry
+			txHash *string
+		)
+		if err := rows.Scan(&e.ID, &e.Plan, &e.Delta, &e.Reason, &e.JobID, &txHash, &e.ActorID, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.PaymentTxHash = safeStr(txHash)
+		credits.History = append(credits.History, e)
+	}
+	return credits, rows.Err()
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrEmailAlreadyVerified     = errors.New("email is already verified")
+	ErrVerificationEmailTooSoon = errors.New("a verification email was sent recently; try again in a minute")
+	ErrEmailNotVerified         = errors.New("verify your email address first (POST /auth/verify-email/resend sends a new link)")
+)
+
+// VerificationResendInterval is the minimum time between two verification emails to one user.
+const VerificationResendInterval = time.Minute
+
+// SendVerificationEmail emails the user a signed link that verifies their address
+//
+// Process:
+// 1. Claim the send slot: the user must be unverified and not have been sent a link in the last VerificationResendInterval
+// 2. Sign a token bound to the user and their current email (utils.GenerateEmailVerificationToken)
+// 3. Send verifyURL?token=<token> with the configured Mailer
+//
+// Parameters:
+// - userID: UUID string of the user
+// - secret: Signing key (JWT_SECRET)
+// - verifyURL: Frontend page that posts the token to POST /auth/verify-email (EMAIL_VERIFY_URL)
+// - ttl: Link lifetime (EMAIL_VERIFICATION_TTL_HOURS)
+//
+// Returns:
+// - ErrEmailAlreadyVerified, ErrVerificationEmailTooSoon, pgx.ErrNoRows (unknown user)
+// - ErrMailerUnavailable or the mailer's error if sending fails
+//
+// Usage: Called after POST /auth/register and by POST /auth/verify-email/resend
+func SendVerificationEmail(userID, secret, verifyURL string, ttl time.Duration) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return pgx.ErrNoRows
+	}
+
+	var name, email string
+	err = db.Pool.QueryRow(context.Background(),
+		`UPDATE users SET email_verification_sent_at = NOW()
+		 WHERE id = $1 AND NOT email_verified
+		   AND (email_verification_sent_at IS NULL OR email_verification_sent_at < NOW() - make_interval(secs => $2))
+		 RETURNING name, email`,
+		uid, VerificationResendInterval.Seconds(),
+	).Scan(&name, &email)
+	if err == pgx.ErrNoRows {
+		var verified bool
+		if err := db.Pool.QueryRow(context.Background(),
+			`SELECT email_verified FROM users WHERE id = $1`, uid).Scan(&verified); err != nil {
+			return err
+		}
+		if verified {
+			return ErrEmailAlreadyVerified
+		}
+		return ErrVerificationEmailTooSoon
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := utils.GenerateEmailVerificationToken(uid.String(), email, secret, ttl)
+	if err != nil {
+		return err
+	}
+	link := verifyURL + "?token=" + url.QueryEscape(token)
+
+	err = sendMail(context.Background(), Email{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm your email address to start posting jobs and posts:\n\n%s\n\nThe link expires in %s. If you didn't create an account, ignore this email.\n",
+			name, link, ttl),
+	})
+	if err != nil {
+		// Give the slot back so the user can retry right away
+		_, _ = db.Pool.Exec(context.Background(),
+			`UPDATE users SET email_verification_sent_at = NULL WHERE id = $1`, uid)
+		return err
+	}
+	return nil
+}
+
+// VerifyEmail marks the address in a verification token as verified
+//
+// Verifying twice with a valid link succeeds again. A link stops working once the
+// user's email no longer matches the one it was issued for.
+//
+// Returns:
+// - utils.ErrInvalidEmailToken for bad, expired or outdated tokens
+// - Database error otherwise
+//
+// Usage: Called by POST /auth/verify-email endpoint
+func VerifyEmail(token, secret string) error {
+	userID, email, err := utils.ParseEmailVerificationToken(token, secret)
+	if err != nil {
+		return err
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return utils.ErrInvalidEmailToken
+	}
+
+	tag, err := db.Pool.Exec(context.Background(),
+		`UPDATE users SET email_verified = TRUE, email_verified_at = COALESCE(email_verified_at, NOW())
+		 WHERE id = $1 AND email = $2`,
+		uid, email)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrInvalidEmailToken
+	}
+	return nil
+}
+
+// IsEmailVerified reports whether the user has verified their email address.
+// A missing user is not verified.
+//
+// Usage: Called by middleware.EmailVerifiedRequired
+func IsEmailVerified(userID string) (bool, error) {
+	var verified bool
+	err := db.Pool.QueryRow(context.Background(),
+		"SELECT EXISTS(SELECT 1 FROM users WHERE id=$1 AND email_verified)", userID,
+	).Scan(&verified)
+	return verified, err
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+)
+
+// gazetteerCSV is the offline city gazetteer shipped with the repo.
+// Format: name,country,latitude,longitude,utc_offset (lines starting with # are comments).
+//
+//go:embed data/gazetteer.csv
+var gazetteerCSV string
+
+var (
+	ErrInvalidNear     = errors.New("near must be \"lat,lng\" or a known city name")
+	ErrInvalidLocation = errors.New("invalid location")
+)
+
+// Work arrangements stored in jobs.work_arrangement
+const (
+	WorkRemote = "remote"
+	WorkHybrid = "hybrid"
+	WorkOnsite = "onsite"
+)
+
+// IsValidWorkArrangement reports whether v is remote, hybrid or onsite.
+func IsValidWorkArrangement(v string) bool {
+	return v == WorkRemote || v == WorkHybrid || v == WorkOnsite
+}
+
+// City is a single gazetteer entry.
+type City struct {
+	Name      string
+	Country   string
+	Latitude  float64
+	Longitude float64
+	UTCOffset float64
+}
+
+var (
+	gazetteerOnce sync.Once
+	gazetteer     map[string][]City // lower-case name -> entries (population order)
+)
+
+// loadGazetteer parses the embedded CSV once on first use.
+func loadGazetteer() {
+	gazetteer = make(map[string][]City)
+
+	r := csv.NewReader(strings.NewReader(gazetteerCSV))
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		log.Println("gazetteer parse failed:", err)
+		return
+	}
+
+	for _, rec := range records {
+		if len(rec) != 5 {
+			continue
+		}
+		lat, err1 := strconv.ParseFloat(rec[2], 64)
+		lng, err2 := strconv.ParseFloat(rec[3], 64)
+		tz, err3 := strconv.ParseFloat(rec[4], 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		key := strings.ToLower(rec[0])
+		gazetteer[key] = append(gazetteer[key], City{
+			Name:      rec[0],
+			Country:   rec[1],
+			Latitude:  lat,
+			Longitude: lng,
+			UTCOffset: tz,
+		})
+	}
+}
+
+// LookupCity geocodes a city name using the offline gazetteer.
+//
+// Parameters:
+// - name: City name, case-insensitive (e.g., "berlin", "San Francisco")
+// - country: Optional ISO 3166-1 alpha-2 code to disambiguate (e.g., "US")
+//
+// Returns:
+// - City with coordinates and UTC offset
+// - false if the city is not in the gazetteer
+//
+// Without a country, the most populous match wins.
+func LookupCity(name, country string) (City, bool) {
+	gazetteerOnce.Do(loadGazetteer)
+
+	for _, c := range gazetteer[strings.ToLower(strings.TrimSpace(name))] {
+		if country == "" || strings.EqualFold(c.Country, country) {
+			return c, true
+		}
+	}
+	return City{}, false
+}
+
+// ResolvePlace validates a structured job location, normalizes it in place,
+// and geocodes the city with the offline gazetteer when coordinates are missing.
+//
+// Rules:
+// - WorkArrangement (lower-cased) must be remote, hybrid or onsite when set
+// - Country (upper-cased) must be a 2-letter ISO 3166-1 code when set
+// - Latitude and Longitude must be given together and be within range
+// - UTCOffset must be between -12 and +14
+//
+// Cities missing from the gazetteer are accepted without coordinates
+// (such jobs don't appear in radius searches).
+//
+// Returns an error wrapping ErrInvalidLocation describing the first problem found.
+//
+// Usage: Called by POST /jobs and PUT /jobs/:id handlers before saving
+func ResolvePlace(p *models.Place) error {
+	if p == nil {
+		return nil
+	}
+
+	p.WorkArrangement = strings.ToLower(strings.TrimSpace(p.WorkArrangement))
+	if p.WorkArrangement != "" && !IsValidWorkArrangement(p.WorkArrangement) {
+		return fmt.Errorf("%w: work_arrangement must be remote, hybrid or onsite", ErrInvalidLocation)
+	}
+
+	p.Country = strings.ToUpper(strings.TrimSpace(p.Country))
+	if p.Country != "" && (len(p.Country) != 2 || strings.Trim(p.Country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "") {
+		return fmt.Errorf("%w: country must be a 2-letter ISO 3166-1 code", ErrInvalidLocation)
+	}
+
+	if (p.Latitude == nil) != (p.Longitude == nil) {
+		return fmt.Errorf("%w: latitude and longitude must be provided together", ErrInvalidLocation)
+	}
+	if p.Latitude != nil && (*p.Latitude < -90 || *p.Latitude > 90 || *p.Longitude < -180 || *p.Longitude > 180) {
+		return fmt.Errorf("%w: coordinates out of range", ErrInvalidLocation)
+	}
+	if p.UTCOffset != nil && (*p.UTCOffset < -12 || *p.UTCOffset > 14) {
+		return fmt.Errorf("%w: utc_offset must be between -12 and 14", ErrInvalidLocation)
+	}
+
+	p.City = strings.TrimSpace(p.City)
+	if p.City != "" {
+		if c, ok := LookupCity(p.City, p.Country); ok {
+			if p.Latitude == nil {
+				p.Latitude, p.Longitude = &c.Latitude, &c.Longitude
+			}
+			if p.Country == "" {
+				p.Country = c.Country
+			}
+			if p.UTCOffset == nil {
+				p.UTCOffset = &c.UTCOffset
+			}
+		}
+	}
+	return nil
+}
+
+// ParseNear parses the ?near= query parameter into coordinates.
+// Accepts "lat,lng" (e.g., "52.52,13.405") or a gazetteer city name (e.g., "Berlin").
+//
+// Returns ErrInvalidNear if the value is neither.
+func ParseNear(near string) (lat, lng float64, err error) {
+	if parts := strings.Split(near, ","); len(parts) == 2 {
+		lat, err1 := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		lng, err2 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err1 == nil && err2 == nil {
+			if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+				return 0, 0, ErrInvalidNear
+			}
+			return lat, lng, nil
+		}
+	}
+
+	if c, ok := LookupCity(near, ""); ok {
+		return c.Latitude, c.Longitude, nil
+	}
+	return 0, 0, ErrInvalidNear
+}
+
+// earthRadiusKm is the mean Earth radius used for haversine distances.
+const earthRadiusKm = 6371.0
+
+// HaversineKm returns the great-circle distance in kilometres between two points.
+// Near-antipodal points are clamped to half the circumference instead of NaN.
+func HaversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
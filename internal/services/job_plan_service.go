@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrPlanNotFound          = errors.New("pricing plan not found")
+	ErrInvalidPlan           = errors.New("invalid pricing plan")
+	ErrPlanExists            = errors.New("pricing plan already exists")
+	ErrPlanMethodUnavailable = errors.New("plan not offered for payment method")
+)
+
+// DefaultJobPlan is the plan used when POST /jobs doesn't name one.
+const DefaultJobPlan = "basic"
+
+var planSlugPattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// jobPlanColumns is the column list shared by job_plans SELECT queries, in scanJobPlan order.
+const jobPlanColumns = `slug, name, price_wei::text, prices, duration_days, perks, active, sort_order, created_at, updated_at`
+
+func scanJobPlan(row rowScanner) (*models.JobPlan, error) {
+	var (
+		p         models.JobPlan
+		pricesRaw []byte
+		perksRaw  []byte
+	)
+	err := row.Scan(&p.Slug, &p.Name, &p.PriceWei, &pricesRaw, &p.DurationDays, &perksRaw, &p.Active, &p.SortOrder, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if len(pricesRaw) > 0 {
+		_ = json.Unmarshal(pricesRaw, &p.Prices)
+	}
+	if p.Prices == nil {
+		p.Prices = map[string]string{}
+	}
+	if len(perksRaw) > 0 {
+		_ = json.Unmarshal(perksRaw, &p.Perks)
+	}
+	if p.Perks == nil {
+		p.Perks = []string{}
+	}
+	return &p, nil
+}
+
+// ListJobPlans retrieves pricing plans in display order.
+//
+// Parameters:
+// - includeInactive: Also return plans that new jobs can no longer choose (admin view)
+//
+// Usage: Called by GET /plans and GET /admin/plans endpoints
+func ListJobPlans(includeInactive bool) ([]models.JobPlan, error) {
+	rows, err := db.Pool.Query(context.Background(),
+		`SELECT `+jobPlanColumns+` FROM job_plans WHERE active OR $1 ORDER BY sort_order, slug`,
+		includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []models.JobPlan
+	for rows.Next() {
+		p, err := scanJobPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *p)
+	}
+	return out, rows.Err()
+}
+
+// GetJobPlan retrieves a plan by slug, active or not.
+//
+// Returns ErrPlanNotFound if no plan has this slug.
+func GetJobPlan(slug string) (*models.JobPlan, error) {
+	p, err := scanJobPlan(db.Pool.QueryRow(context.Background(),
+		`SELECT `+jobPlanColumns+` FROM job_plans WHERE slug = $1`, slug))
+	if err == pgx.ErrNoRows {
+		return nil, ErrPlanNotFound
+	}
+	return p, err
+}
+
+// planPrice returns what a plan costs when paid with a payment method, in the method's
+// smallest unit: its prices entry, or price_wei for evm_native. Other methods need their
+// own entry, since price_wei means nothing in their units (e.g. a 6-decimal token).
+//
+// Returns:
+// - The price, always positive
+// - ErrPlanMethodUnavailable (wrapped) if the plan has no price for the method
+// - An error if the stored amount isn't a positive integer
+func planPrice(p *models.JobPlan, method string) (*big.Int, error) {
+	amount, ok := p.Prices[method]
+	if !ok {
+		if method != PaymentMethodEVMNative {
+			return nil, fmt.Errorf("%w: plan %q has no %s price", ErrPlanMethodUnavailable, p.Slug, method)
+		}
+		amount = p.PriceWei
+	}
+	price, ok := new(big.Int).SetString(amount, 10)
+	if !ok || price.Sign() <= 0 {
+		return nil, fmt.Errorf("plan %q has a malformed %s price %q", p.Slug, method, amount)
+	}
+	return price, nil
+}
+
+// validateJobPlan checks plan fields and normalizes them in place.
+//
+// Rules:
+// - Slug: 1-32 characters of a-z, 0-9 and _
+// - Name is required
+// - PriceWei and Prices values: positive integers (decimal strings)
+// - Prices keys: payment method names (a-z, 0-9 and _)
+// - DurationDays: 1..365
+//
+// Returns an error wrapping ErrInvalidPlan describing the first problem found.
+func validateJobPlan(p *models.JobPlan) error {
+	p.Slug = strings.ToLower(strings.TrimSpace(p.Slug))
+	p.Name = strings.TrimSpace(p.Name)
+	if !planSlugPattern.MatchString(p.Slug) {
+		return fmt.Errorf("%w: slug must be 1-32 characters of a-z, 0-9 and _", ErrInvalidPlan)
+	}
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidPlan)
+	}
+	price, ok := new(big.Int).SetString(strings.TrimSpace(p.PriceWei), 10)
+	if !ok || price.Sign() <= 0 {
+		return fmt.Errorf("%w: price_wei must be a positive integer", ErrInvalidPlan)
+	}
+	p.PriceWei = price.String()
+	if p.Prices == nil {
+		p.Prices = map[string]string{}
+	}
+	for method, amount := range p.Prices {
+		if !planSlugPattern.MatchString(method) {
+			return fmt.Errorf("%w: invalid payment method %q in prices", ErrInvalidPlan, method)
+		}
+		price, ok := new(big.Int).SetString(strings.TrimSpace(amount), 10)
+		if !ok || price.Sign() <= 0 {
+			return fmt.Errorf("%w: prices.%s must be a positive integer", ErrInvalidPlan, method)
+		}
+		p.Prices[method] = price.String()
+	}
+	if p.DurationDays < 1 || p.DurationDays > 365 {
+		return fmt.Errorf("%w: duration_days must be between 1 and 365", ErrInvalidPlan)
+	}
+	if p.Perks == nil {
+		p.Perks = []string{}
+	}
+	return nil
+}
+
+// CreateJobPlan adds a pricing plan
+//
+// Returns:
+// - *models.JobPlan as stored
+// - ErrInvalidPlan (wrapped) if validation fails, ErrPlanExists if the slug is taken
+// - Other error if database operation fails
+//
+// Usage: Called by POST /admin/plans endpoint
+func CreateJobPlan(p models.JobPlan) (*models.JobPlan, error) {
+	if err := validateJobPlan(&p); err != nil {
+		return nil, err
+	}
+	prices, _ := json.Marshal(p.Prices)
+	perks, _ := json.Marshal(p.Perks)
+
+	created, err := scanJobPlan(db.Pool.QueryRow(context.Background(),
+		`INSERT INTO job_plans (slug, name, price_wei, prices, duration_days, perks, active, sort_order, created_at, updated_at)
+		 VALUES ($1,$2,$3::numeric,$4,$5,$6,$7,$8,NOW(),NOW())
+		 RETURNING `+jobPlanColumns,
+		p.Slug, p.Name, p.PriceWei, prices, p.DurationDays, perks, p.Active, p.SortOrder))
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return nil, ErrPlanExists
+	}
+	return created, err
+}
+
+// UpdateJobPlan modifies a pricing plan
+//
+// Supported fields in updates map:
+// - "name": string
+// - "price_wei": string - decimal wei amount
+// - "prices": map[string]string - per-payment-method prices; replaces the map
+// - "duration_days": int
+// - "perks": []string - replaces the list
+// - "active": bool - inactive plans can't be chosen for new jobs
+// - "sort_order": int
+//
+// Price and duration changes apply to new purchases only: pending payments keep the
+// amount recorded in the payments ledger, listed jobs keep their expires_at.
+//
+// Returns:
+// - *models.JobPlan after the update
+// - ErrPlanNotFound, ErrInvalidPlan (wrapped), or database error
+//
+// Usage: Called by PUT /admin/plans/:slug endpoint
+func UpdateJobPlan(slug string, updates map[string]interface{}) (*models.JobPlan, error) {
+	p, err := GetJobPlan(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := updates["name"].(string); ok {
+		p.Name = v
+	}
+	if v, ok := updates["price_wei"].(string); ok {
+		p.PriceWei = v
+	}
+	if v, ok := updates["prices"].(map[string]string); ok {
+		p.Prices = v
+	}
+	if v, ok := updates["duration_days"].(int); ok {
+		p.DurationDays = v
+	}
+	if v, ok := updates["perks"].([]string); ok {
+		p.Perks = v
+	}
+	if v, ok := updates["active"].(bool); ok {
+		p.Active = v
+	}
+	if v, ok := updates["sort_order"].(int); ok {
+		p.SortOrder = v
+	}
+	if err := validateJobPlan(p); err != nil {
+		return nil, err
+	}
+	prices, _ := json.Marshal(p.Prices)
+	perks, _ := json.Marshal(p.Perks)
+
+	return scanJobPlan(db.Pool.QueryRow(context.Background(),
+		`UPDATE job_plans
+		 SET name = $2, price_wei = $3::numeric, prices = $4, duration_days = $5, perks = $6, active = $7, sort_order = $8, updated_at = NOW()
+		 WHERE slug = $1
+		 RETURNING `+jobPlanColumns,
+		p.Slug, p.Name, p.PriceWei, prices, p.DurationDays, perks, p.Active, p.SortOrder))
+}
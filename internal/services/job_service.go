@@ -4,270 +4,742 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
 	"time"
-"log"
-"strconv"
+
 	"github.com/Akshatt02/job-portal-backend/internal/db"
 	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
 	"github.com/google/uuid"
 )
 
-var ErrJobNotFound = errors.New("job not found")
+var (
+	ErrJobNotFound      = errors.New("job not found")
+	ErrInvalidTxHash    = errors.New("invalid transaction hash format")
+	ErrInvalidStatus    = errors.New("invalid job status")
+	ErrStatusTransition = errors.New("job status transition not allowed")
+	ErrPaymentRequired  = errors.New("payment required (payment_tx_hash missing)")
+	ErrTxHashReused     = errors.New("payment_tx_hash has already been used")
+	ErrInvalidSalary    = errors.New("invalid salary")
+)
+
+// Job statuses stored in jobs.status
+//
+// Lifecycle:
+// - draft: created but not listed; publishing starts the expiry clock
+// - published: listed on GET /jobs until expires_at
+// - paused: temporarily hidden by the owner, expiry clock keeps running
+// - expired: set by the expiry sweeper once expires_at has passed; renew to republish
+// - filled: position filled, no longer accepting applications
+// - closed: withdrawn by the owner via POST /jobs/:id/close
+// - pending_payment: payment broadcast but not yet confirmed; the confirmation worker publishes it (or returns it to draft)
+// - payment_failed: payment rejected or not confirmed in time; the job can only be closed or deleted
+const (
+	JobStatusDraft          = "draft"
+	JobStatusPublished      = "published"
+	JobStatusPaused         = "paused"
+	JobStatusExpired        = "expired"
+	JobStatusFilled         = "filled"
+	JobStatusClosed         = "closed"
+	JobStatusPendingPayment = "pending_payment"
+	JobStatusPaymentFailed  = "payment_failed"
+)
+
+// jobStatusTransitions lists the statuses an owner may move a job to from each status.
+// Expiry is only set by the sweeper; leaving "expired" requires RenewJob.
+// Leaving "pending_payment" other than by closing is up to the payment confirmation worker.
+var jobStatusTransitions = map[string][]string{
+	JobStatusDraft:          {JobStatusPublished, JobStatusClosed},
+	JobStatusPublished:      {JobStatusPaused, JobStatusFilled, JobStatusClosed},
+	JobStatusPaused:         {JobStatusPublished, JobStatusFilled, JobStatusClosed},
+	JobStatusExpired:        {JobStatusFilled, JobStatusClosed},
+	JobStatusFilled:         {},
+	JobStatusClosed:         {},
+	JobStatusPendingPayment: {JobStatusClosed},
+	JobStatusPaymentFailed:  {JobStatusClosed},
+}
+
+// Salary periods stored in jobs.salary_period, with the multiplier to a yearly
+// amount (must match the salary_*_annual generated columns).
+var salaryPeriodsPerYear = map[string]float64{
+	"hourly":  2080,
+	"monthly": 12,
+	"yearly":  1,
+}
+
+// ValidateSalary checks a structured salary and normalizes it in place.
+//
+// Rules:
+// - nil is allowed (salary not disclosed)
+// - At least one of Min/Max is required; amounts must be non-negative and Min <= Max
+// - Currency is required: 3-letter ISO 4217 code (uppercased)
+// - Period defaults to "yearly"; must be hourly, monthly or yearly
+//
+// Returns an error wrapping ErrInvalidSalary describing the first problem found.
+//
+// Usage: Called by POST /jobs and PUT /jobs/:id handlers before saving
+func ValidateSalary(sal *models.Salary) error {
+	if sal == nil {
+		return nil
+	}
+	if sal.Min == nil && sal.Max == nil {
+		return fmt.Errorf("%w: min or max is required", ErrInvalidSalary)
+	}
+	if (sal.Min != nil && *sal.Min < 0) || (sal.Max != nil && *sal.Max < 0) {
+		return fmt.Errorf("%w: amounts cannot be negative", ErrInvalidSalary)
+	}
+	if sal.Min != nil && sal.Max != nil && *sal.Max < *sal.Min {
+		return fmt.Errorf("%w: max must be greater than or equal to min", ErrInvalidSalary)
+	}
+
+	sal.Currency = strings.ToUpper(strings.TrimSpace(sal.Currency))
+	if len(sal.Currency) != 3 || strings.Trim(sal.Currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return fmt.Errorf("%w: currency must be a 3-letter ISO 4217 code", ErrInvalidSalary)
+	}
+
+	sal.Period = strings.ToLower(strings.TrimSpace(sal.Period))
+	if sal.Period == "" {
+		sal.Period = "yearly"
+	}
+	if _, ok := salaryPeriodsPerYear[sal.Period]; !ok {
+		return fmt.Errorf("%w: period must be hourly, monthly or yearly", ErrInvalidSalary)
+	}
+	return nil
+}
+
+// IsValidSalaryPeriod reports whether period is hourly, monthly or yearly.
+func IsValidSalaryPeriod(period string) bool {
+	_, ok := salaryPeriodsPerYear[period]
+	return ok
+}
+
+// salaryArgs splits a salary into the salary_min, salary_max, salary_currency
+// and salary_period column values (all NULL when sal is nil).
+func salaryArgs(sal *models.Salary) (min, max *float64, currency, period *string) {
+	if sal == nil {
+		return nil, nil, nil, nil
+	}
+	return sal.Min, sal.Max, &sal.Currency, &sal.Period
+}
+
+// IsValidJobStatus reports whether status is one of the known job statuses.
+func IsValidJobStatus(status string) bool {
+	_, ok := jobStatusTransitions[status]
+	return ok
+}
+
+// jobColumns is the column list shared by all job SELECT queries, in scanJob order.
+const jobColumns = `id, title, description, skills, salary_min, salary_max, salary_currency, salary_period, location,
+	work_arrangement, country, city, latitude, longitude, utc_offset,
+	category, employment_type, seniority, user_id, company_id, payment_tx_hash, plan, status, expires_at, created_at`
+
+// Full-text search settings for jobs.search_vector (see migrations/database.sql).
+// The vector weights title (A) above skills (B), location (C) and description (D).
+const (
+	searchConfig        = "english"
+	titleHeadlineOpts   = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	snippetHeadlineOpts = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter= ... "
+)
+
+// anyTermQuery returns the tsquery SQL for the search text in arg (a placeholder or column):
+// plainto_tsquery with its ANDs turned into ORs, so any term may match. Job search
+// (ListJobsWithFilters) and saved-search alerts (MatchSavedSearches) both use it, so an alert
+// fires exactly for jobs the same q finds.
+func anyTermQuery(arg string) string {
+	return `replace(plainto_tsquery('` + searchConfig + `', ` + arg + `)::text, '&', '|')::tsquery`
+}
+
+// rowScanner is satisfied by both pgx.Row and pgx.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanJob reads a row selected with jobColumns into a Job model.
+// Any extra destinations are scanned from columns following jobColumns.
+func scanJob(row rowScanner, extra ...interface{}) (*models.Job, error) {
+	var (
+		j                models.Job
+		skillsRaw        []byte
+		salaryMin        *float64
+		salaryMax        *float64
+		currency, period *string
+		location         *string
+		arrangement      *string
+		country, city    *string
+		category         *string
+		employmentType   *string
+		seniority        *string
+		paymentTx        *string
+	)
+	dest := []interface{}{&j.ID, &j.Title, &j.Description, &skillsRaw, &salaryMin, &salaryMax, &currency, &period, &location,
+		&arrangement, &country, &city, &j.Latitude, &j.Longitude, &j.UTCOffset,
+		&category, &employmentType, &seniority, &j.UserID, &j.CompanyID, &paymentTx, &j.Plan, &j.Status, &j.ExpiresAt, &j.CreatedAt}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(skillsRaw) > 0 {
+		_ = json.Unmarshal(skillsRaw, &j.Skills)
+	}
+	if salaryMin != nil || salaryMax != nil {
+		j.Salary = &models.Salary{Min: salaryMin, Max: salaryMax, Currency: safeStr(currency), Period: safeStr(period)}
+	}
+	j.Location = safeStr(location)
+	j.WorkArrangement = safeStr(arrangement)
+	j.Country = safeStr(country)
+	j.City = safeStr(city)
+	j.Category = safeStr(category)
+	j.EmploymentType = safeStr(employmentType)
+	j.Seniority = safeStr(seniority)
+	j.PaymentTxHash = safeStr(paymentTx)
+	return &j, nil
+}
+
+// validateTxHash checks the transaction hash format ("0x" + 64 hex characters).
+func validateTxHash(tx string) error {
+	if len(tx) != 66 || tx[:2] != "0x" {
+		return ErrInvalidTxHash
+	}
+	for _, c := range tx[2:] {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return ErrInvalidTxHash
+		}
+	}
+	return nil
+}
+
+// CreateJobInput holds the fields accepted when creating a job posting.
+//
+// Fields:
+// - Title, Description: Required job text
+// - Skills: Required skills (optional, can be nil)
+// - Salary: Structured salary (optional, validated with ValidateSalary)
+// - Location: Free-text location label
+// - Place: Structured location (optional, resolved with ResolvePlace)
+// - Category, EmploymentType, Seniority: Optional classification (see GET /jobs/taxonomy)
+// - UserID: UUID string of job poster
+// - CompanyID: Optional company the job is posted for (poster must be an owner or recruiter)
+// - PaymentTxHash: Payment reference; a transaction hash (66 char format) for EVM payment methods; empty to spend a posting credit
+// - PaymentMethod: Payment provider, "evm_native" (default), "erc20" or "fake" (see PaymentVerifier)
+// - ChainID: Chain the payment was made on (payments ledger key together with the hash)
+// - Plan: Pricing plan slug (default: DefaultJobPlan); sets the price and listing duration
+// - Status: "draft" or "published" (default: published)
+// - Confirmations: Confirmations the payment needs before the job goes live (see StartPaymentConfirmationWorker)
+type CreateJobInput struct {
+	Title          string
+	Description    string
+	Skills         []string
+	Salary         *models.Salary
+	Location       string
+	Place          models.Place
+	Category       string
+	EmploymentType string
+	Seniority      string
+	UserID         string
+	CompanyID      string
+	PaymentTxHash  string
+	PaymentMethod  string
+	ChainID        int64
+	Plan           string
+	Status         string
+	Confirmations  int
+}
 
 // CreateJob creates a new job posting
 //
-// Blockchain Payment Requirement:
-// - User must provide paymentTx (Sepolia ETH transaction hash)
-// - Hash format: "0x" + 64 hexadecimal characters (66 chars total)
-// - Validates format but does NOT verify transaction on-chain
+// Payment Requirement:
+// - Either PaymentTxHash, checked by the PaymentVerifier registered for PaymentMethod
+// - Or a posting credit for the plan (the company's credits for company jobs, otherwise the poster's)
+// - EVM methods need a transaction hash: "0x" + 64 hexadecimal characters (66 chars total)
+// - Without an evm_native verifier (ETH_RPC_URL unset) only that format is checked
 //
 // Process:
 // 1. Parse and validate user ID (UUID format)
-// 2. Require payment_tx_hash for security/audit trail
-// 3. Validate transaction hash format
-// 4. Generate UUID for job
-// 5. Serialize skills array to JSON
-// 6. Insert into database with all metadata
-//
-// Parameters:
-// - title: Job position title
-// - description: Full job description
-// - skills: []string - Required skills (optional, can be nil)
-// - salary: Salary range or "Competitive" (optional)
-// - location: Job location
-// - userIDStr: UUID string of job poster
-// - paymentTx: Sepolia transaction hash (66 char format)
+// 2. Without payment_tx_hash, pay with a posting credit (steps 3, 6 and 7 are skipped)
+// 3. Resolve the payment method and validate the reference format
+// 4. Validate salary, geocode location, check classification, plan (must be active) and initial status (draft or published)
+// 5. If CompanyID is set, require the poster to be an owner or recruiter of the company
+// 6. Reject payments already in the ledger, then verify the plan's price for the method was paid
+// 7. If the payment pays for the job but is not settled or has fewer than Confirmations, the job is stored as pending_payment; unknown transactions are rejected
+// 8. Published jobs get expires_at = now + the plan's duration_days; drafts and pending jobs get none yet
+// 9. Insert the job and its payments ledger entry (or credit debit) in one transaction
+// 10. Published jobs are matched against saved searches in the background
 //
 // Returns:
-// - Job ID (UUID string) on success
+// - Job ID (UUID string) and the job's initial status on success
+// - *PaymentConsumedError (matches ErrTxHashReused) if the hash was already used for any job
+// - ErrPlanNotFound or ErrInvalidPlan (wrapped) for an unknown or inactive plan
+// - ErrUnknownPaymentMethod (wrapped) if PaymentMethod isn't enabled
+// - ErrNoCredits if no payment_tx_hash is given and no credit is left for the plan
+// - ErrPaymentNotFound if the transaction isn't known to the node; ErrPaymentRejected (wrapped) if it doesn't pay for the job, mined or not
 // - Error if validation fails or database error
 //
 // Usage: Called by POST /jobs handler after blockchain payment
-func CreateJob(title, description string, skills []string, salary, location, userIDStr, paymentTx string) (string, error) {
+func CreateJob(in CreateJobInput) (string, string, error) {
 	// Ensure user id is valid uuid
-	userID, err := uuid.Parse(userIDStr)
+	userID, err := uuid.Parse(in.UserID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Without a payment_tx_hash the posting is paid with a prepaid credit (see PurchaseCredits)
+	useCredit := in.PaymentTxHash == ""
+
+	// Resolve the payment provider and check the reference format (a 0x + 64 hex transaction hash for EVM methods)
+	var method string
+	if !useCredit {
+		var verifier PaymentVerifier
+		if method, verifier, err = paymentVerifierFor(in.PaymentMethod); err != nil {
+			return "", "", err
+		}
+		if err := validatePaymentReference(verifier, in.PaymentTxHash); err != nil {
+			return "", "", err
+		}
+	}
+
+	if err := ValidateSalary(in.Salary); err != nil {
+		return "", "", err
+	}
+	if err := ResolvePlace(&in.Place); err != nil {
+		return "", "", err
+	}
+	if err := ValidateTaxonomy(in.Category, in.EmploymentType, in.Seniority); err != nil {
+		return "", "", err
+	}
+
+	var companyID *uuid.UUID
+	if in.CompanyID != "" {
+		cid, err := uuid.Parse(in.CompanyID)
+		if err != nil {
+			return "", "", ErrCompanyNotFound
+		}
+		if err := requireCompanyRole(cid.String(), in.UserID, companyManagerRoles...); err != nil {
+			return "", "", err
+		}
+		companyID = &cid
+	}
+
+	status := in.Status
+	if status == "" {
+		status = JobStatusPublished
+	}
+	if status != JobStatusDraft && status != JobStatusPublished {
+		return "", "", ErrInvalidStatus
+	}
+
+	planSlug := in.Plan
+	if planSlug == "" {
+		planSlug = DefaultJobPlan
+	}
+	plan, err := GetJobPlan(planSlug)
 	if err != nil {
-		return "", err
+		return "", "", err
+	}
+	if !plan.Active {
+		return "", "", fmt.Errorf("%w: plan %q is no longer available", ErrInvalidPlan, plan.Slug)
 	}
 
-	// Enforce a payment_tx_hash for posting (as per assignment). Remove if not desired.
-	if paymentTx == "" {
-		return "", errors.New("payment required before posting job (payment_tx_hash missing)")
+	var (
+		claim         PaymentClaim
+		confirmations int
+		confirmStatus = status
+	)
+	if !useCredit {
+		price, err := planPrice(plan, method)
+		if err != nil {
+			return "", "", err
+		}
+		if err := checkPaymentUnused(context.Background(), in.ChainID, in.PaymentTxHash); err != nil {
+			return "", "", err
+		}
+		if claim, err = newPaymentClaim(in.PaymentTxHash, in.UserID, price); err != nil {
+			return "", "", err
+		}
+
+		// A payment that isn't confirmed yet parks the job until the confirmation worker decides.
+		// Verifiers only report ErrPaymentPending for transactions that already pay for this
+		// claim; unknown hashes (ErrPaymentNotFound) are rejected so they can't squat the ledger
+		confirmations, err = verifyPayment(context.Background(), method, claim, in.Confirmations)
+		switch {
+		case errors.Is(err, ErrPaymentPending):
+			status = JobStatusPendingPayment
+		case err != nil:
+			return "", "", err
+		}
 	}
 
-	// Validate transaction hash format (must be 66 characters starting with 0x)
-	if len(paymentTx) != 66 || paymentTx[:2] != "0x" {
-		return "", errors.New("invalid transaction hash format")
+	now := time.Now()
+	var expiresAt *time.Time
+	if status == JobStatusPublished {
+		t := now.AddDate(0, 0, plan.DurationDays)
+		expiresAt = &t
 	}
 
 	jobID := uuid.New()
 	skillsBytes := []byte("null")
-	if skills != nil {
-		b, _ := json.Marshal(skills)
+	if in.Skills != nil {
+		b, _ := json.Marshal(in.Skills)
 		skillsBytes = b
 	}
 
-	_, err = db.Pool.Exec(context.Background(),
-		`INSERT INTO jobs (id, title, description, skills, salary, location, user_id, payment_tx_hash, created_at)
-		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`,
-		jobID, title, description, skillsBytes, salary, location, userID, paymentTx, time.Now(),
+	salaryMin, salaryMax, currency, period := salaryArgs(in.Salary)
+
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return "", "", err
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(),
+		`INSERT INTO jobs (id, title, description, skills, salary_min, salary_max, salary_currency, salary_period,
+		                   location, work_arrangement, country, city, latitude, longitude, utc_offset,
+		                   category, employment_type, seniority,
+		                   user_id, company_id, payment_tx_hash, plan, status, expires_at, created_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25)`,
+		jobID, in.Title, in.Description, skillsBytes, salaryMin, salaryMax, currency, period,
+		in.Location, nullStr(in.Place.WorkArrangement), nullStr(in.Place.Country), nullStr(in.Place.City),
+		in.Place.Latitude, in.Place.Longitude, in.Place.UTCOffset,
+		nullStr(in.Category), nullStr(in.EmploymentType), nullStr(in.Seniority),
+		userID, companyID, nullStr(in.PaymentTxHash), plan.Slug, status, expiresAt, now,
 	)
 	if err != nil {
-		return "", err
+		return "", "", err
+	}
+
+	switch {
+	case useCredit:
+		err = consumeCredit(context.Background(), tx, creditAccount{UserID: userID, CompanyID: companyID}, plan.Slug, jobID)
+	case status == JobStatusPendingPayment:
+		err = recordPendingPayment(context.Background(), tx, in.ChainID, method, claim, jobID, userID, confirmStatus, confirmations)
+	default:
+		err = recordPayment(context.Background(), tx, in.ChainID, method, claim, jobID, userID, PaymentPurposeJobPost, confirmations)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if err := tx.Commit(context.Background()); err != nil {
+		return "", "", err
+	}
+
+	if status == JobStatusPublished {
+		notifyJobAlerts(jobID.String())
 	}
 
-	return jobID.String(), nil
+	return jobID.String(), status, nil
+}
+
+// Coordinates is a latitude/longitude pair in decimal degrees.
+type Coordinates struct {
+	Lat float64
+	Lng float64
 }
 
-// ListJobs retrieves recent job postings
+// JobFilter holds the optional filters for job listings.
 //
-// Process:
-// 1. Query jobs table ordered by creation date (newest first)
-// 2. Limit results to specified count
-// 3. Unmarshal skills JSON arrays for each job
-// 4. Convert UUIDs to strings for API response
+// Fields:
+// - Limit: Max number of jobs to return. If <= 0, defaults to 100
+// - Skill: Filter jobs that require a specific skill (partial match in JSON array)
+// - Location: Filter jobs by location (case-insensitive, partial match)
+// - SalaryMin: Jobs whose range reaches at least this amount (0 = no filter)
+// - SalaryMax: Jobs whose range starts at or below this amount (0 = no filter)
+// - SalaryPeriod: Period SalaryMin/SalaryMax are expressed in (default: yearly); jobs are compared on yearly equivalents
+// - Currency: Filter by salary currency (ISO 4217); salary amounts are not converted between currencies
+// - WorkArrangements: Any of remote, hybrid, onsite
+// - Countries: Any of these ISO 3166-1 alpha-2 codes
+// - City: Exact city name (case-insensitive)
+// - Near/RadiusKm: Jobs with coordinates within RadiusKm of Near (haversine); results carry distance_km
+// - UTCOffsetMin/UTCOffsetMax: Jobs whose utc_offset lies within this range (e.g., EU timezones: 0..3)
+// - Categories, EmploymentTypes, SeniorityLevels: Any of these classification values
+// - Status: Filter by status; only honoured together with OwnerID or CompanyID
+// - OwnerID: Restrict results to jobs posted by this user
+// - CompanyID: Restrict results to jobs of this company (membership is checked by the caller)
+// - Query: Keyword search over title, skills, location and description (relevance-ordered, with snippets)
+// - Cursor: Opaque next_cursor from the previous page ("" for the first page)
+//
+// Without OwnerID or CompanyID, only published jobs whose expires_at is in the future are returned.
+type JobFilter struct {
+	Limit            int
+	Skill            string
+	Location         string
+	SalaryMin        float64
+	SalaryMax        float64
+	SalaryPeriod     string
+	Currency         string
+	WorkArrangements []string
+	Countries        []string
+	City             string
+	Near             *Coordinates
+	RadiusKm         float64
+	UTCOffsetMin     *float64
+	UTCOffsetMax     *float64
+	Categories       []string
+	EmploymentTypes  []string
+	SeniorityLevels  []string
+	Status           string
+	OwnerID          string
+	CompanyID        string
+	Query            string
+	Cursor           string
+}
+
+// ListJobs retrieves a page of recent published job postings
 //
 // Parameters:
 // - limit: Max number of jobs to return. If <= 0, defaults to 100
+// - cursor: Opaque next_cursor from the previous page ("" for the first page)
 //
 // Returns:
-// - []*models.Job array of job listings
-// - Error if database query fails
+// - []*models.Job array of job listings, newest first
+// - next cursor ("" when there are no more jobs)
+// - Error if the cursor is invalid or database query fails
 //
 // Usage: Called by GET /jobs endpoint (public, no auth required)
-func ListJobs(limit int) ([]*models.Job, error) {
-	if limit <= 0 {
-		limit = 100
-	}
-
-	rows, err := db.Pool.Query(context.Background(),
-		`SELECT id, title, description, skills, salary, location, user_id, payment_tx_hash, created_at
-		 FROM jobs
-		 ORDER BY created_at DESC
-		 LIMIT $1`, limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	out := []*models.Job{}
-	for rows.Next() {
-		var (
-			id                 uuid.UUID
-			title, description string
-			skillsRaw          []byte
-			salary, location   string
-			userID             uuid.UUID
-			paymentTx          *string
-			createdAt          time.Time
-		)
-		err := rows.Scan(&id, &title, &description, &skillsRaw, &salary, &location, &userID, &paymentTx, &createdAt)
-		if err != nil {
-			return nil, err
-		}
-
-		var skills []string
-		if len(skillsRaw) > 0 {
-			_ = json.Unmarshal(skillsRaw, &skills)
-		}
-
-		px := ""
-		if paymentTx != nil {
-			px = *paymentTx
-		}
-
-		job := &models.Job{
-			// GetJobByID retrieves a single job posting by ID
-			//
-			// Process:
-			// 1. Parse job ID (UUID format)
-			// 2. Query database for matching job
-			// 3. Unmarshal skills JSON array
-			// 4. Return complete job model
-			//
-			// Parameters:
-			// - jobIDStr: UUID string of job to fetch
-			//
-			// Returns:
-			// - *models.Job with all fields populated
-			// - ErrJobNotFound if job doesn't exist
-			// - Other error if database query fails
-			//
-			// Usage: Called by GET /jobs/:id endpoint
-			// Note: Client also receives match_score (computed separately)
-			ID:            id,
-			Title:         title,
-			Description:   description,
-			Skills:        skills,
-			Salary:        salary,
-			Location:      location,
-			UserID:        userID,
-			PaymentTxHash: px,
-			CreatedAt:     createdAt,
-		}
-		out = append(out, job)
-	}
-	return out, nil
+// Note: Draft, paused, expired, filled and closed jobs are excluded; fetch them by ID instead
+func ListJobs(limit int, cursor string) ([]*models.Job, string, error) {
+	return ListJobsWithFilters(JobFilter{Limit: limit, Cursor: cursor})
 }
 
 // ListJobsWithFilters retrieves job postings with optional filters.
 //
-// Supports filtering by:
-// - skill: Filter jobs that require a specific skill (partial match in JSON array)
-// - location: Filter jobs by location (case-insensitive)
-// - salaryMin: Filter jobs above minimum salary (numeric comparison)
+// Process:
+// 1. Owner listings (OwnerID or CompanyID set) return that user's or company's jobs, optionally by Status
+// 2. Public listings return only published, unexpired jobs
+// 3. Apply keyword search (ts_rank ordering), skill/location/salary filters and radius search
+// 4. Without a keyword, order by creation date (newest first)
+// 5. Page with keyset pagination on (created_at, id), or (rank, created_at, id) for searches
 //
 // Parameters:
-// - limit: Max number of jobs to return
-// - skill: Optional skill filter (e.g., "go", "react")
-// - location: Optional location filter (e.g., "Remote", "New York")
-// - salaryMin: Optional minimum salary threshold
+// - f: JobFilter with optional filters (see JobFilter)
 //
 // Returns:
 // - []*models.Job array of filtered job listings
-// - Error if database query fails
+// - next cursor ("" when there are no more jobs)
+// - utils.ErrInvalidCursor if the cursor is malformed, or database error
 //
 // Usage: Called by GET /jobs endpoint with query parameters
-func ListJobsWithFilters(limit int, skill, location string, salaryMin int) ([]*models.Job, error) {
-	if limit <= 0 {
-		limit = 100
+func ListJobsWithFilters(f JobFilter) ([]*models.Job, string, error) {
+	if f.Limit <= 0 {
+		f.Limit = 100
 	}
 
-	query := `
-		SELECT id, title, description, skills, salary, location, user_id, payment_tx_hash, created_at
-		FROM jobs
-		WHERE 1=1
-	`
+	var after *utils.Cursor
+	if f.Cursor != "" {
+		c, err := utils.DecodeCursor(f.Cursor)
+		if err != nil || (c.Rank != nil) != (f.Query != "") {
+			return nil, "", utils.ErrInvalidCursor
+		}
+		after = &c
+	}
 
+	from := `jobs`
+	where := ` WHERE 1=1`
 	var args []interface{}
 	argCount := 1
 
-	if skill != "" {
-		query += ` AND skills::text ILIKE '%' || $` + strconv.Itoa(argCount) + ` || '%'`
-		args = append(args, skill)
+	// Keyword search: any query term may match (OR), ranking rewards matching more terms
+	if f.Query != "" {
+		from += `, (SELECT ` + anyTermQuery(`$`+strconv.Itoa(argCount)) + ` AS tsq) q`
+		where += ` AND search_vector @@ q.tsq`
+		args = append(args, f.Query)
+		argCount++
+	}
+
+	if f.OwnerID != "" || f.CompanyID != "" {
+		if f.OwnerID != "" {
+			where += ` AND user_id = $` + strconv.Itoa(argCount)
+			args = append(args, f.OwnerID)
+			argCount++
+		}
+		if f.CompanyID != "" {
+			where += ` AND company_id = $` + strconv.Itoa(argCount)
+			args = append(args, f.CompanyID)
+			argCount++
+		}
+
+		if f.Status != "" {
+			where += ` AND status = $` + strconv.Itoa(argCount)
+			args = append(args, f.Status)
+			argCount++
+		}
+	} else {
+		where += ` AND status = $` + strconv.Itoa(argCount) + ` AND (expires_at IS NULL OR expires_at > NOW())`
+		args = append(args, JobStatusPublished)
+		argCount++
+	}
+
+	if f.Skill != "" {
+		where += ` AND skills::text ILIKE '%' || $` + strconv.Itoa(argCount) + ` || '%'`
+		args = append(args, f.Skill)
+		argCount++
+	}
+
+	if f.Location != "" {
+		where += ` AND location ILIKE $` + strconv.Itoa(argCount)
+		args = append(args, "%"+f.Location+"%")
+		argCount++
+	}
+
+	// Salary filters compare yearly equivalents so hourly/monthly/yearly ranges mix correctly
+	perYear := salaryPeriodsPerYear["yearly"]
+	if p, ok := salaryPeriodsPerYear[f.SalaryPeriod]; ok {
+		perYear = p
+	}
+	if f.SalaryMin > 0 {
+		where += ` AND COALESCE(salary_max_annual, salary_min_annual) >= $` + strconv.Itoa(argCount)
+		args = append(args, f.SalaryMin*perYear)
+		argCount++
+	}
+	if f.SalaryMax > 0 {
+		where += ` AND COALESCE(salary_min_annual, salary_max_annual) <= $` + strconv.Itoa(argCount)
+		args = append(args, f.SalaryMax*perYear)
+		argCount++
+	}
+	if f.Currency != "" {
+		where += ` AND salary_currency = $` + strconv.Itoa(argCount)
+		args = append(args, strings.ToUpper(f.Currency))
 		argCount++
 	}
 
-	if location != "" {
-		query += ` AND location ILIKE $` + strconv.Itoa(argCount)
-		args = append(args, "%"+location+"%")
+	if len(f.WorkArrangements) > 0 {
+		where += ` AND work_arrangement = ANY($` + strconv.Itoa(argCount) + `)`
+		args = append(args, f.WorkArrangements)
+		argCount++
+	}
+	if len(f.Countries) > 0 {
+		where += ` AND country = ANY($` + strconv.Itoa(argCount) + `)`
+		args = append(args, f.Countries)
+		argCount++
+	}
+	for column, values := range map[string][]string{
+		"category":        f.Categories,
+		"employment_type": f.EmploymentTypes,
+		"seniority":       f.SeniorityLevels,
+	} {
+		if len(values) > 0 {
+			where += ` AND ` + column + ` = ANY($` + strconv.Itoa(argCount) + `)`
+			args = append(args, values)
+			argCount++
+		}
+	}
+	if f.City != "" {
+		where += ` AND lower(city) = lower($` + strconv.Itoa(argCount) + `)`
+		args = append(args, f.City)
+		argCount++
+	}
+	if f.UTCOffsetMin != nil {
+		where += ` AND utc_offset >= $` + strconv.Itoa(argCount)
+		args = append(args, *f.UTCOffsetMin)
+		argCount++
+	}
+	if f.UTCOffsetMax != nil {
+		where += ` AND utc_offset <= $` + strconv.Itoa(argCount)
+		args = append(args, *f.UTCOffsetMax)
 		argCount++
 	}
 
-	query += ` ORDER BY created_at DESC LIMIT $` + strconv.Itoa(argCount)
-	args = append(args, limit)
+	// Radius search: cheap latitude band (uses the index) then exact haversine distance.
+	// LEAST keeps rounding near antipodal points from pushing asin's argument past 1 (an SQL error).
+	if f.Near != nil {
+		lat, lng, r := strconv.Itoa(argCount), strconv.Itoa(argCount+1), strconv.Itoa(argCount+2)
+		where += ` AND latitude BETWEEN $` + lat + `::float8 - $` + r + `::float8 / 111.045 AND $` + lat + `::float8 + $` + r + `::float8 / 111.045` +
+			` AND 2 * ` + strconv.FormatFloat(earthRadiusKm, 'f', -1, 64) + ` * asin(LEAST(1.0, sqrt(` +
+			`power(sin(radians(latitude - $` + lat + `::float8) / 2), 2) + ` +
+			`cos(radians($` + lat + `::float8)) * cos(radians(latitude)) * power(sin(radians(longitude - $` + lng + `::float8) / 2), 2)` +
+			`))) <= $` + r + `::float8`
+		args = append(args, f.Near.Lat, f.Near.Lng, f.RadiusKm)
+		argCount += 3
+	}
+
+	// Keyset pagination: continue strictly after the last row of the previous page
+	if after != nil {
+		if f.Query != "" {
+			where += ` AND (ts_rank(search_vector, q.tsq), created_at, id) < ($` + strconv.Itoa(argCount) + `::real, $` + strconv.Itoa(argCount+1) + `, $` + strconv.Itoa(argCount+2) + `)`
+			args = append(args, *after.Rank, after.CreatedAt, after.ID)
+			argCount += 3
+		} else {
+			where += ` AND (created_at, id) < ($` + strconv.Itoa(argCount) + `, $` + strconv.Itoa(argCount+1) + `)`
+			args = append(args, after.CreatedAt, after.ID)
+			argCount += 2
+		}
+	}
+
+	var query string
+	if f.Query != "" {
+		// Rank and limit first, then build headlines only for the returned page
+		query = `
+			SELECT ` + jobColumns + `, rank,
+			       ts_headline('` + searchConfig + `', title, tsq, '` + titleHeadlineOpts + `'),
+			       ts_headline('` + searchConfig + `', description, tsq, '` + snippetHeadlineOpts + `')
+			FROM (
+				SELECT jobs.*, q.tsq, ts_rank(search_vector, q.tsq) AS rank
+				FROM ` + from + where + `
+				ORDER BY rank DESC, created_at DESC, id DESC
+				LIMIT $` + strconv.Itoa(argCount) + `
+			) ranked
+			ORDER BY rank DESC, created_at DESC, id DESC`
+	} else {
+		query = `SELECT ` + jobColumns + ` FROM ` + from + where +
+			` ORDER BY created_at DESC, id DESC LIMIT $` + strconv.Itoa(argCount)
+	}
+	// Fetch one extra row to learn whether another page exists
+	args = append(args, f.Limit+1)
 
 	rows, err := db.Pool.Query(context.Background(), query, args...)
 	if err != nil {
 		log.Println("DB ERROR:", err)
-		return nil, err
+		return nil, "", err
 	}
 	defer rows.Close()
 
 	out := []*models.Job{}
 	for rows.Next() {
-		var (
-			id                 uuid.UUID
-			title, description string
-			skillsRaw          []byte
-			salary, location   string
-			userID             uuid.UUID
-			paymentTx          *string
-			createdAt          time.Time
-		)
+		if f.Query == "" {
+			job, err := scanJob(rows)
+			if err != nil {
+				return nil, "", err
+			}
+			out = append(out, job)
+			continue
+		}
 
-		err := rows.Scan(
-			&id, &title, &description, &skillsRaw,
-			&salary, &location, &userID, &paymentTx, &createdAt,
+		var (
+			rank                    float64
+			titleHighlight, snippet string
 		)
+		job, err := scanJob(rows, &rank, &titleHighlight, &snippet)
 		if err != nil {
-			return nil, err
-		}
-
-		var skills []string
-		if len(skillsRaw) > 0 {
-			_ = json.Unmarshal(skillsRaw, &skills)
+			return nil, "", err
 		}
+		job.SearchRank = &rank
+		job.TitleHighlight = titleHighlight
+		job.Snippet = snippet
+		out = append(out, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
 
-		px := ""
-		if paymentTx != nil {
-			px = *paymentTx
+	if f.Near != nil {
+		for _, job := range out {
+			if job.Latitude != nil && job.Longitude != nil {
+				d := math.Round(HaversineKm(f.Near.Lat, f.Near.Lng, *job.Latitude, *job.Longitude)*10) / 10
+				job.DistanceKm = &d
+			}
 		}
+	}
 
-		job := &models.Job{
-			ID:            id,
-			Title:         title,
-			Description:   description,
-			Skills:        skills,
-			Salary:        salary,
-			Location:      location,
-			UserID:        userID,
-			PaymentTxHash: px,
-			CreatedAt:     createdAt,
-		}
-		out = append(out, job)
+	next := ""
+	if len(out) > f.Limit {
+		out = out[:f.Limit]
+		last := out[len(out)-1]
+		next = utils.EncodeCursor(utils.Cursor{CreatedAt: last.CreatedAt, ID: last.ID, Rank: last.SearchRank})
 	}
-	return out, nil
+	return out, next, nil
 }
 
 // GetJobByID retrieves a single job posting by ID
@@ -282,54 +754,457 @@ func ListJobsWithFilters(limit int, skill, location string, salaryMin int) ([]*m
 // - jobIDStr: UUID string of job to fetch
 //
 // Returns:
-// - *models.Job with all fields populated
+// - *models.Job with all fields populated (including status)
 // - ErrJobNotFound if job doesn't exist
 // - Other error if database query fails
 //
-// Usage: Called by GET /jobs/:id endpoint
-// Note: Client also receives match_score (computed separately)
+// Usage: Internal loader for services and handlers that already checked access
+// Note: Jobs in any status are returned; requests on behalf of a user go through GetVisibleJob
 func GetJobByID(jobIDStr string) (*models.Job, error) {
 	id, err := uuid.Parse(jobIDStr)
 	if err != nil {
 		return nil, err
 	}
 
+	j, err := scanJob(db.Pool.QueryRow(context.Background(),
+		`SELECT `+jobColumns+` FROM jobs WHERE id=$1`, id))
+	if err != nil {
+		return nil, ErrJobNotFound
+	}
+	return j, nil
+}
+
+// isUnlistedJobStatus reports whether a job in status has not been made public yet:
+// drafts and jobs waiting on (or failed by) their posting payment.
+func isUnlistedJobStatus(status string) bool {
+	switch status {
+	case JobStatusDraft, JobStatusPendingPayment, JobStatusPaymentFailed:
+		return true
+	}
+	return false
+}
+
+// GetVisibleJob retrieves a job by ID on behalf of a user
+//
+// Process:
+// 1. Load the job with GetJobByID
+// 2. Published, paused, expired, filled and closed jobs are returned to anyone
+// 3. Draft, pending_payment and payment_failed jobs are only returned to the poster or a member of the job's company (as getViewableJob)
+//
+// Parameters:
+// - jobIDStr: UUID string of job to fetch
+// - userID: UUID string of the caller
+//
+// Returns:
+// - *models.Job with all fields populated (including status)
+// - ErrJobNotFound if the job doesn't exist or the caller may not see it yet
+// - Other error if database query fails
+//
+// Usage: Called by GET /jobs/:id, POST /jobs/:id/save and POST /jobs/:id/apply
+// Note: Unlisted jobs look the same as missing ones, so their IDs can't be probed
+func GetVisibleJob(jobIDStr, userID string) (*models.Job, error) {
+	job, err := GetJobByID(jobIDStr)
+	if err != nil {
+		return nil, err
+	}
+	if !isUnlistedJobStatus(job.Status) {
+		return job, nil
+	}
+	if err := checkJobMember(job, userID, CompanyRoles...); err != nil {
+		if err == ErrNotJobOwner {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// getOwnedJob loads a job and verifies that userID may manage it: the poster,
+// or an owner or recruiter of the company the job belongs to.
+// Returns ErrJobNotFound or ErrNotJobOwner on failure.
+func getOwnedJob(jobIDStr, userID string) (*models.Job, error) {
+	return getJobForMember(jobIDStr, userID, companyManagerRoles...)
+}
+
+// getViewableJob is like getOwnedJob but also admits company viewers.
+func getViewableJob(jobIDStr, userID string) (*models.Job, error) {
+	return getJobForMember(jobIDStr, userID, CompanyRoles...)
+}
+
+// getJobForMember loads a job and verifies that userID posted it or holds
+// one of roles in the job's company.
+func getJobForMember(jobIDStr, userID string, roles ...string) (*models.Job, error) {
+	job, err := GetJobByID(jobIDStr)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkJobMember(job, userID, roles...); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// checkJobMember returns nil if userID posted job or holds one of roles in its company,
+// ErrNotJobOwner if not, or a database error.
+func checkJobMember(job *models.Job, userID string, roles ...string) error {
+	if job.UserID.String() == userID {
+		return nil
+	}
+	if job.CompanyID != nil {
+		err := requireCompanyRole(job.CompanyID.String(), userID, roles...)
+		if err == nil {
+			return nil
+		}
+		if err != ErrNotCompanyMember && err != ErrCompanyRole {
+			return err
+		}
+	}
+	return ErrNotJobOwner
+}
+
+// UpdateJob modifies a job posting managed by the caller
+//
+// Supported fields in updates map:
+// - "title": string - Job position title
+// - "description": string - Full job description
+// - "salary": *models.Salary - Structured salary (validated with ValidateSalary)
+// - "location": string - Free-text location label
+// - "category", "employment_type", "seniority": string - Classification ("" clears it)
+// - "place": *models.Place - Structured location, replaced as a whole (resolved with ResolvePlace)
+// - "skills": []string - Required skills
+//
+// Process:
+// 1. Verify the job exists and belongs to userID
+// 2. Build SQL UPDATE statement dynamically (parameterized)
+// 3. Only provided fields are updated (partial updates supported)
+//
+// Parameters:
+// - jobIDStr: UUID string of job to update
+// - userID: UUID string of the caller (the poster, or an owner/recruiter of the job's company)
+// - updates: Map with field names as keys and new values
+//
+// Returns:
+// - nil on success
+// - ErrInvalidSalary, ErrInvalidLocation, ErrInvalidTaxonomy, ErrJobNotFound, ErrNotJobOwner, or database error
+//
+// Usage: Called by PUT /jobs/:id endpoint
+func UpdateJob(jobIDStr, userID string, updates map[string]interface{}) error {
+	job, err := getOwnedJob(jobIDStr, userID)
+	if err != nil {
+		return err
+	}
+
+	args := []interface{}{}
+	setClauses := []string{}
+	argIdx := 1
+
+	for _, field := range []string{"title", "description", "location"} {
+		if v, ok := updates[field].(string); ok {
+			setClauses = append(setClauses, field+` = $`+itoa(argIdx))
+			args = append(args, v)
+			argIdx++
+		}
+	}
+	for _, field := range []string{"category", "employment_type", "seniority"} {
+		if v, ok := updates[field].(string); ok {
+			setClauses = append(setClauses, field+` = $`+itoa(argIdx))
+			args = append(args, nullStr(v))
+			argIdx++
+		}
+	}
+	category, _ := updates["category"].(string)
+	employmentType, _ := updates["employment_type"].(string)
+	seniority, _ := updates["seniority"].(string)
+	if err := ValidateTaxonomy(category, employmentType, seniority); err != nil {
+		return err
+	}
+	if v, ok := updates["skills"].([]string); ok {
+		skillsBytes, _ := json.Marshal(v)
+		setClauses = append(setClauses, `skills = $`+itoa(argIdx))
+		args = append(args, skillsBytes)
+		argIdx++
+	}
+	if v, ok := updates["place"].(*models.Place); ok && v != nil {
+		if err := ResolvePlace(v); err != nil {
+			return err
+		}
+		setClauses = append(setClauses,
+			`work_arrangement = $`+itoa(argIdx), `country = $`+itoa(argIdx+1), `city = $`+itoa(argIdx+2),
+			`latitude = $`+itoa(argIdx+3), `longitude = $`+itoa(argIdx+4), `utc_offset = $`+itoa(argIdx+5))
+		args = append(args, nullStr(v.WorkArrangement), nullStr(v.Country), nullStr(v.City), v.Latitude, v.Longitude, v.UTCOffset)
+		argIdx += 6
+	}
+	if v, ok := updates["salary"].(*models.Salary); ok {
+		if err := ValidateSalary(v); err != nil {
+			return err
+		}
+		salaryMin, salaryMax, currency, period := salaryArgs(v)
+		setClauses = append(setClauses,
+			`salary_min = $`+itoa(argIdx), `salary_max = $`+itoa(argIdx+1),
+			`salary_currency = $`+itoa(argIdx+2), `salary_period = $`+itoa(argIdx+3))
+		args = append(args, salaryMin, salaryMax, currency, period)
+		argIdx += 4
+	}
+
+	if len(setClauses) == 0 {
+		return nil // nothing to update
+	}
+
+	args = append(args, job.ID)
+	query := `UPDATE jobs SET ` + join(setClauses, ", ") + ` WHERE id = $` + itoa(argIdx)
+
+	_, err = db.Pool.Exec(context.Background(), query, args...)
+	return err
+}
+
+// CloseJob marks a job posting as closed so it no longer appears in listings.
+// The job remains reachable by ID with status "closed". It is SetJobStatus to closed,
+// so filled and already closed jobs can't be closed.
+//
+// Returns ErrJobNotFound, ErrNotJobOwner, ErrStatusTransition, or database error.
+//
+// Usage: Called by POST /jobs/:id/close endpoint
+func CloseJob(jobIDStr, userID string) error {
+	return SetJobStatus(jobIDStr, userID, JobStatusClosed)
+}
+
+// DeleteJob permanently removes a job posting managed by the caller.
+// Applications for the job are removed by ON DELETE CASCADE.
+//
+// Returns ErrJobNotFound, ErrNotJobOwner, or database error.
+//
+// Usage: Called by DELETE /jobs/:id endpoint
+func DeleteJob(jobIDStr, userID string) error {
+	job, err := getOwnedJob(jobIDStr, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Pool.Exec(context.Background(), `DELETE FROM jobs WHERE id = $1`, job.ID)
+	return err
+}
+
+// SetJobStatus moves a job managed by the caller to a new status.
+//
+// Allowed transitions are listed in jobStatusTransitions. Publishing a draft
+// starts its expiry clock (expires_at = now + the plan's duration_days) and triggers saved-search alerts.
+//
+// Parameters:
+// - jobIDStr: UUID string of the job
+// - userID: UUID string of the caller (the poster, or an owner/recruiter of the job's company)
+// - status: Target status
+//
+// Returns:
+// - ErrInvalidStatus, ErrStatusTransition, ErrJobNotFound, ErrNotJobOwner, or database error
+//
+// Usage: Called by PUT /jobs/:id/status endpoint
+func SetJobStatus(jobIDStr, userID, status string) error {
+	if !IsValidJobStatus(status) {
+		return ErrInvalidStatus
+	}
+
+	job, err := getOwnedJob(jobIDStr, userID)
+	if err != nil {
+		return err
+	}
+
+	allowed := false
+	for _, next := range jobStatusTransitions[job.Status] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return ErrStatusTransition
+	}
+
+	if job.Status == JobStatusDraft && status == JobStatusPublished {
+		plan, err := GetJobPlan(job.Plan)
+		if err != nil {
+			return err
+		}
+		_, err = db.Pool.Exec(context.Background(),
+			`UPDATE jobs SET status = $1, expires_at = $2 WHERE id = $3`,
+			status, time.Now().AddDate(0, 0, plan.DurationDays), job.ID)
+		if err != nil {
+			return err
+		}
+		notifyJobAlerts(job.ID.String())
+		return nil
+	}
+
+	_, err = db.Pool.Exec(context.Background(),
+		`UPDATE jobs SET status = $1 WHERE id = $2`, status, job.ID)
+	return err
+}
+
+// RenewJob extends a job's listing period and republishes it if it had expired.
+//
+// Process:
+// 1. Verify the caller may manage the job; only published, paused or expired jobs can be renewed
+// 2. If requirePayment, require a payment_tx_hash not yet in the payments ledger, verified by its payment method like CreateJob (mined, no extra confirmations)
+// 3. New expires_at = max(now, current expires_at) + the plan's duration_days; a payment must cover the plan's current price
+// 4. Record the renewal in job_renewals for the audit trail and the payment in the ledger
+//
+// Parameters:
+// - jobIDStr: UUID string of the job
+// - userID: UUID string of the caller (the poster, or an owner/recruiter of the job's company)
+// - paymentTx: Transaction hash paying for the renewal (may be empty if not required)
+// - paymentMethod: Payment provider of paymentTx (empty for DefaultPaymentMethod)
+// - chainID: Chain the payment was made on
+// - requirePayment: Whether a payment_tx_hash is mandatory
+//
+// Returns:
+// - *models.Job with updated status and expires_at
+// - ErrPaymentRequired, ErrInvalidTxHash, ErrTxHashReused, ErrUnknownPaymentMethod or a payment verification error for payment problems
+// - ErrStatusTransition, ErrJobNotFound, ErrNotJobOwner, or database error
+//
+// Usage: Called by POST /jobs/:id/renew endpoint
+func RenewJob(jobIDStr, userID, paymentTx, paymentMethod string, chainID int64, requirePayment bool) (*models.Job, error) {
+	job, err := getOwnedJob(jobIDStr, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch job.Status {
+	case JobStatusPublished, JobStatusPaused, JobStatusExpired:
+	default:
+		return nil, ErrStatusTransition
+	}
+
+	if paymentTx == "" && requirePayment {
+		return nil, ErrPaymentRequired
+	}
+	plan, err := GetJobPlan(job.Plan)
+	if err != nil {
+		return nil, err
+	}
 	var (
-		title, description string
-		skillsRaw          []byte
-		salary, location   string
-		userID             uuid.UUID
-		paymentTx          *string
-		createdAt          time.Time
+		method        string
+		claim         PaymentClaim
+		confirmations int
 	)
-	err = db.Pool.QueryRow(context.Background(),
-		`SELECT title, description, skills, salary, location, user_id, payment_tx_hash, created_at
-		 FROM jobs WHERE id=$1`, id).
-		Scan(&title, &description, &skillsRaw, &salary, &location, &userID, &paymentTx, &createdAt)
+	if paymentTx != "" {
+		var verifier PaymentVerifier
+		if method, verifier, err = paymentVerifierFor(paymentMethod); err != nil {
+			return nil, err
+		}
+		if err := validatePaymentReference(verifier, paymentTx); err != nil {
+			return nil, err
+		}
+		if err := checkPaymentUnused(context.Background(), chainID, paymentTx); err != nil {
+			return nil, err
+		}
+		price, err := planPrice(plan, method)
+		if err != nil {
+			return nil, err
+		}
+		if claim, err = newPaymentClaim(paymentTx, userID, price); err != nil {
+			return nil, err
+		}
+		if confirmations, err = verifyPayment(context.Background(), method, claim, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	base := time.Now()
+	if job.ExpiresAt != nil && job.ExpiresAt.After(base) {
+		base = *job.ExpiresAt
+	}
+	expiresAt := base.AddDate(0, 0, plan.DurationDays)
+
+	status := job.Status
+	if status == JobStatusExpired {
+		status = JobStatusPublished
+	}
+
+	tx, err := db.Pool.Begin(context.Background())
 	if err != nil {
-		return nil, ErrJobNotFound
+		return nil, err
 	}
+	defer tx.Rollback(context.Background())
 
-	var skills []string
-	if len(skillsRaw) > 0 {
-		_ = json.Unmarshal(skillsRaw, &skills)
+	_, err = tx.Exec(context.Background(),
+		`UPDATE jobs SET status = $1, expires_at = $2 WHERE id = $3`,
+		status, expiresAt, job.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var renewalTx *string
+	if paymentTx != "" {
+		renewalTx = &paymentTx
+	}
+	_, err = tx.Exec(context.Background(),
+		`INSERT INTO job_renewals (id, job_id, payment_tx_hash, expires_at, created_at)
+		 VALUES ($1,$2,$3,$4,$5)`,
+		uuid.New(), job.ID, renewalTx, expiresAt, time.Now())
+	if err != nil {
+		return nil, err
 	}
 
-	pt := ""
-	if paymentTx != nil {
-		pt = *paymentTx
+	if paymentTx != "" {
+		payer, _ := uuid.Parse(userID)
+		if err := recordPayment(context.Background(), tx, chainID, method, claim, job.ID, payer, PaymentPurposeJobRenewal, confirmations); err != nil {
+			return nil, err
+		}
 	}
 
-	j := &models.Job{
-		ID:            id,
-		Title:         title,
-		Description:   description,
-		Skills:        skills,
-		Salary:        salary,
-		Location:      location,
-		UserID:        userID,
-		PaymentTxHash: pt,
-		CreatedAt:     createdAt,
+	if err := tx.Commit(context.Background()); err != nil {
+		return nil, err
 	}
-	return j, nil
+
+	job.Status = status
+	job.ExpiresAt = &expiresAt
+	return job, nil
+}
+
+// ExpireJobs marks published and paused jobs whose expires_at has passed as expired.
+//
+// Returns:
+// - Number of jobs expired
+// - Error if database update fails
+//
+// Usage: Called periodically by StartJobExpirySweeper
+func ExpireJobs(ctx context.Context) (int64, error) {
+	tag, err := db.Pool.Exec(ctx,
+		`UPDATE jobs SET status = $1
+		 WHERE status IN ($2, $3) AND expires_at IS NOT NULL AND expires_at <= NOW()`,
+		JobStatusExpired, JobStatusPublished, JobStatusPaused)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// StartJobExpirySweeper runs ExpireJobs every interval until ctx is cancelled.
+// Runs once immediately so jobs that expired while the server was down are swept on startup.
+//
+// Usage: Started from main() as a background goroutine
+func StartJobExpirySweeper(ctx context.Context, interval time.Duration) {
+	sweep := func() {
+		n, err := ExpireJobs(ctx)
+		if err != nil {
+			log.Println("job expiry sweep failed:", err)
+			return
+		}
+		if n > 0 {
+			log.Printf("job expiry sweep: expired %d job(s)", n)
+		}
+	}
+
+	go func() {
+		sweep()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sweep()
+			}
+		}
+	}()
 }
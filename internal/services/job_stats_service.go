@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// Job event types recorded in job_events.
+const (
+	JobEventView   = "view"
+	JobEventSave   = "save"
+	JobEventUnsave = "unsave"
+	JobEventApply  = "apply"
+)
+
+// MaxJobStatsDays caps the length of the GET /jobs/:id/stats series.
+const MaxJobStatsDays = 365
+
+// RecordJobEvent appends an event to a job's activity log.
+//
+// Parameters:
+// - jobID: UUID string of the job
+// - userID: UUID string of the acting user ("" for anonymous)
+// - eventType: One of the JobEvent* constants
+//
+// Usage: Called by the job, saved job and application handlers after a successful request
+func RecordJobEvent(jobID, userID, eventType string) error {
+	_, err := db.Pool.Exec(context.Background(),
+		`INSERT INTO job_events (job_id, user_id, type, created_at) VALUES ($1,$2,$3,NOW())`,
+		jobID, nullStr(userID), eventType,
+	)
+	return err
+}
+
+// jobStatsQuery aggregates JobStats for the jobs in $1 (a uuid[]).
+const jobStatsQuery = `
+	SELECT j.id,
+	       COALESCE(v.views, 0), COALESCE(v.unique_viewers, 0),
+	       (SELECT COUNT(*) FROM saved_jobs s WHERE s.job_id = j.id),
+	       COALESCE(a.applications, 0), a.avg_match_score
+	FROM jobs j
+	LEFT JOIN (
+		SELECT job_id, COUNT(*) AS views, COUNT(DISTINCT user_id) AS unique_viewers
+		FROM job_events
+		WHERE type = 'view' AND job_id = ANY($1)
+		GROUP BY job_id
+	) v ON v.job_id = j.id
+	LEFT JOIN (
+		SELECT job_id, COUNT(*) AS applications, AVG(match_score)::float8 AS avg_match_score
+		FROM applications
+		WHERE job_id = ANY($1)
+		GROUP BY job_id
+	) a ON a.job_id = j.id
+	WHERE j.id = ANY($1)
+`
+
+// GetJobsStats aggregates counters for a set of jobs.
+//
+// Returns:
+// - map from job ID to its JobStats (jobs that don't exist are absent)
+// - error if database query fails
+func GetJobsStats(jobIDs []uuid.UUID) (map[uuid.UUID]models.JobStats, error) {
+	out := make(map[uuid.UUID]models.JobStats, len(jobIDs))
+	if len(jobIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := db.Pool.Query(context.Background(), jobStatsQuery, jobIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s models.JobStats
+		if err := rows.Scan(&s.JobID, &s.Views, &s.UniqueViewers, &s.Saves, &s.Applications, &s.AvgMatchScore); err != nil {
+			return nil, err
+		}
+		out[s.JobID] = s
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// ListMyJobsWithStats retrieves a page of the caller's own jobs with their counters
+//
+// Parameters:
+// - userID: UUID string of the poster
+// - status: Optional status filter ("" for all)
+// - limit, cursor: Keyset pagination as in ListJobs
+//
+// Returns:
+// - jobs newest first, with stats keyed by job ID
+// - next cursor ("" when there are no more jobs)
+// - utils.ErrInvalidCursor or database error
+//
+// Usage: Called by GET /me/jobs endpoint
+func ListMyJobsWithStats(userID, status string, limit int, cursor string) ([]*models.Job, map[uuid.UUID]models.JobStats, string, error) {
+	jobs, next, err := ListJobsWithFilters(JobFilter{OwnerID: userID, Status: status, Limit: limit, Cursor: cursor})
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	ids := make([]uuid.UUID, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+	stats, err := GetJobsStats(ids)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return jobs, stats, next, nil
+}
+
+// GetJobStats returns a job's totals and daily activity series
+//
+// Process:
+// 1. Verify the caller is the poster or a member of the job's company
+// 2. Aggregate totals (same counters as GET /me/jobs)
+// 3. Build one row per day for the last `days` days (including today), zero-filled
+//
+// Parameters:
+// - jobIDStr: UUID string of the job
+// - userID: UUID string of the caller
+// - days: Length of the series, 1..MaxJobStatsDays
+//
+// Returns:
+// - totals and the daily series, oldest day first
+// - ErrJobNotFound, ErrNotJobOwner, or database error
+//
+// Usage: Called by GET /jobs/:id/stats endpoint
+func GetJobStats(jobIDStr, userID string, days int) (*models.JobStats, []models.JobStatsDay, error) {
+	job, err := getViewableJob(jobIDStr, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats, err := GetJobsStats([]uuid.UUID{job.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+	totals := stats[job.ID]
+
+	rows, err := db.Pool.Query(context.Background(), `
+		SELECT to_char(d.day, 'YYYY-MM-DD'),
+		       COUNT(*) FILTER (WHERE e.type = 'view'),
+		       COUNT(DISTINCT e.user_id) FILTER (WHERE e.type = 'view'),
+		       COUNT(*) FILTER (WHERE e.type = 'save'),
+		       COUNT(*) FILTER (WHERE e.type = 'apply')
+		FROM generate_series((CURRENT_DATE - ($2::int - 1))::timestamp, CURRENT_DATE::timestamp, interval '1 day') AS d(day)
+		LEFT JOIN job_events e
+		       ON e.job_id = $1 AND e.created_at >= d.day AND e.created_at < d.day + interval '1 day'
+		GROUP BY d.day
+		ORDER BY d.day`,
+		job.ID, days,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var series []models.JobStatsDay
+	for rows.Next() {
+		var d models.JobStatsDay
+		if err := rows.Scan(&d.Date, &d.Views, &d.UniqueViewers, &d.Saves, &d.Applications); err != nil {
+			return nil, nil, err
+		}
+		series = append(series, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return &totals, series, nil
+}
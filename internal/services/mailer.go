@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+)
+
+// ErrMailerUnavailable is returned when an account email is needed but no mailer is configured.
+var ErrMailerUnavailable = errors.New("email delivery is not configured")
+
+// Email is a plain-text message to one recipient.
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers account emails such as verification links.
+// Implementations must be safe for concurrent use.
+type Mailer interface {
+	Send(ctx context.Context, msg Email) error
+}
+
+// mailer is nil until main configures one, so account emails fail with ErrMailerUnavailable
+// instead of ending up somewhere unintended.
+var (
+	mailerMu sync.RWMutex
+	mailer   Mailer
+)
+
+// SetMailer replaces the mailer used for account emails. nil disables account emails.
+//
+// Usage: Called once from main based on MAILER
+func SetMailer(m Mailer) {
+	mailerMu.Lock()
+	defer mailerMu.Unlock()
+	mailer = m
+}
+
+// sendMail delivers msg with the configured mailer.
+func sendMail(ctx context.Context, msg Email) error {
+	mailerMu.RLock()
+	m := mailer
+	mailerMu.RUnlock()
+	if m == nil {
+		return ErrMailerUnavailable
+	}
+	return m.Send(ctx, msg)
+}
+
+// LogMailer writes emails to the server log instead of sending them. For local development only:
+// the log then holds live verification and password reset links.
+type LogMailer struct{}
+
+// Send logs the message, including its body (which may contain links with tokens).
+func (LogMailer) Send(_ context.Context, msg Email) error {
+	log.Printf("email to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// MemoryMailer keeps sent emails in memory so tests can read the links they contain.
+type MemoryMailer struct {
+	mu   sync.Mutex
+	sent []Email
+}
+
+// NewMemoryMailer returns an empty in-memory mailer.
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// Send records the message.
+func (m *MemoryMailer) Send(_ context.Context, msg Email) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// Sent returns the messages sent so far, oldest first.
+func (m *MemoryMailer) Sent() []Email {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Email(nil), m.sent...)
+}
+
+// Last returns the most recent message sent to the address, if any.
+func (m *MemoryMailer) Last(to string) (Email, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.sent) - 1; i >= 0; i-- {
+		if strings.EqualFold(m.sent[i].To, to) {
+			return m.sent[i], true
+		}
+	}
+	return Email{}, false
+}
+
+// SMTPMailer sends emails through a plain SMTP server.
+//
+// Fields:
+// - Addr: host:port of the SMTP server (a local fake server such as MailHog works)
+// - Username / Password: PLAIN auth credentials; auth is skipped when Username is empty
+// - From: Sender address
+type SMTPMailer struct {
+	Addr     string
+	Username string
+	Password string
+	From     string
+}
+
+// Send delivers the message as a plain-text email.
+func (m SMTPMailer) Send(_ context.Context, msg Email) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		host, _, err := net.SplitHostPort(m.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.From)
+	fmt.Fprintf(&b, "To: %s\r\n", headerSafe(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerSafe(msg.Subject))
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return smtp.SendMail(m.Addr, auth, m.From, []string{msg.To}, []byte(b.String()))
+}
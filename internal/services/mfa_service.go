@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrMFASetupRequired  = errors.New("start two-factor setup first (POST /me/2fa/setup)")
+	ErrInvalidMFACode    = errors.New("invalid two-factor code")
+	ErrMFALocked         = errors.New("too many invalid two-factor codes; try again later")
+)
+
+// MFAChallengeTTL is how long the mfa_token from the password step of a login stays valid.
+const MFAChallengeTTL = 5 * time.Minute
+
+// Brute-force protection: after mfaMaxFailures wrong codes in a row the account
+// rejects codes for mfaLockout.
+const (
+	mfaMaxFailures = 5
+	mfaLockout     = 15 * time.Minute
+)
+
+// recoveryCodeCount is how many one-time recovery codes enabling 2FA issues.
+const recoveryCodeCount = 10
+
+// newRecoveryCode returns a random recovery code formatted as xxxxx-xxxxx (50 bits).
+func newRecoveryCode() (string, error) {
+	buf := make([]byte, 7)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	s := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf))[:10]
+	return s[:5] + "-" + s[5:], nil
+}
+
+// normalizeMFACode strips spaces and dashes so "123 456" and "ABCDE-FGHIJ" are accepted.
+func normalizeMFACode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code)))
+}
+
+// BeginTOTPSetup generates a new authenticator secret for the user
+//
+// The secret is kept as pending until EnableTOTP confirms a code from it, so
+// calling setup again simply replaces an unconfirmed secret.
+//
+// Parameters:
+// - userID: UUID string of the user
+// - issuer: Name shown in the authenticator app (TOTP_ISSUER)
+//
+// Returns:
+// - secret: Base32 secret for manual entry
+// - uri: otpauth:// URI for a QR code
+// - ErrMFAAlreadyEnabled or database error
+//
+// Usage: Called by POST /me/2fa/setup endpoint
+func BeginTOTPSetup(userID, issuer string) (string, string, error) {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	var email string
+	err = db.Pool.QueryRow(context.Background(),
+		`UPDATE users SET totp_pending_secret = $2 WHERE id = $1 AND NOT totp_enabled RETURNING email`,
+		userID, secret,
+	).Scan(&email)
+	if err == pgx.ErrNoRows {
+		return "", "", ErrMFAAlreadyEnabled
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	return secret, utils.TOTPURI(issuer, email, secret), nil
+}
+
+// EnableTOTP turns on two-factor authentication once the user proves their app works
+//
+// Process:
+// 1. Check the code against the pending secret from BeginTOTPSetup
+// 2. Activate the secret and remember the code's time step (no replay)
+// 3. Replace any recovery codes with recoveryCodeCount new ones (stored hashed)
+//
+// Returns:
+// - []string: The recovery codes, shown to the user only this once
+// - ErrMFAAlreadyEnabled, ErrMFASetupRequired, ErrInvalidMFACode or database error
+//
+// Usage: Called by POST /me/2fa/enable endpoint
+func EnableTOTP(userID, code string) ([]string, error) {
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+
+	var (
+		enabled bool
+		pending *string
+	)
+	err = tx.QueryRow(context.Background(),
+		`SELECT totp_enabled, totp_pending_secret FROM users WHERE id = $1 FOR UPDATE`, userID,
+	).Scan(&enabled, &pending)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if pending == nil {
+		return nil, ErrMFASetupRequired
+	}
+
+	step, ok, err := utils.ValidateTOTP(*pending, normalizeMFACode(code), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	if _, err := tx.Exec(context.Background(),
+		`UPDATE users SET totp_secret = totp_pending_secret, totp_pending_secret = NULL, totp_enabled = TRUE,
+		     totp_last_step = $2, mfa_failures = 0, mfa_locked_until = NULL
+		 WHERE id = $1`,
+		userID, step); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	if _, err := tx.Exec(context.Background(),
+		`DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return nil, err
+	}
+	for i := 0; i < recoveryCodeCount; i++ {
+		c, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(context.Background(),
+			`INSERT INTO mfa_recovery_codes (id, user_id, code_hash, created_at) VALUES ($1, $2, $3, NOW())`,
+			uuid.New(), userID, hashRefreshToken(normalizeMFACode(c))); err != nil {
+			return nil, err
+		}
+		codes = append(codes, c)
+	}
+
+	if err := tx.Commit(context.Background()); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// VerifyMFACode checks a second-factor code for a user with 2FA enabled
+//
+// Accepts a 6-digit authenticator code or an unused recovery code (which is then used up).
+// Authenticator codes are accepted once: a code from the same or an earlier time step is rejected.
+// mfaMaxFailures wrong codes in a row lock the account's second factor for mfaLockout.
+//
+// Returns:
+// - ErrMFANotEnabled, ErrMFALocked, ErrInvalidMFACode or database error
+//
+// Usage: Called by POST /auth/2fa/verify (login) and DisableTOTP
+func VerifyMFACode(userID, code string) error {
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	var (
+		enabled  bool
+		secret   *string
+		lastStep int64
+		locked   bool
+	)
+	err = tx.QueryRow(context.Background(),
+		`SELECT totp_enabled, totp_secret, COALESCE(totp_last_step, 0), COALESCE(mfa_locked_until > NOW(), FALSE)
+		 FROM users WHERE id = $1 FOR UPDATE`, userID,
+	).Scan(&enabled, &secret, &lastStep, &locked)
+	if err != nil {
+		return err
+	}
+	if !enabled || secret == nil {
+		return ErrMFANotEnabled
+	}
+	if locked {
+		return ErrMFALocked
+	}
+
+	code = normalizeMFACode(code)
+	valid := false
+	if len(code) == utils.TOTPDigits {
+		step, ok, err := utils.ValidateTOTP(*secret, code, time.Now())
+		if err != nil {
+			return err
+		}
+		if ok && step > lastStep {
+			valid = true
+			if _, err := tx.Exec(context.Background(),
+				`UPDATE users SET totp_last_step = $2 WHERE id = $1`, userID, step); err != nil {
+				return err
+			}
+		}
+	} else if code != "" {
+		tag, err := tx.Exec(context.Background(),
+			`UPDATE mfa_recovery_codes SET used_at = NOW()
+			 WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`,
+			userID, hashRefreshToken(code))
+		if err != nil {
+			return err
+		}
+		valid = tag.RowsAffected() == 1
+	}
+
+	if !valid {
+		// Count the failure even though the code was wrong, so commit before returning
+		if _, err := tx.Exec(context.Background(),
+			`UPDATE users SET
+			     mfa_locked_until = CASE WHEN mfa_failures + 1 >= $2 THEN NOW() + make_interval(secs => $3) END,
+			     mfa_failures = CASE WHEN mfa_failures + 1 >= $2 THEN 0 ELSE mfa_failures + 1 END
+			 WHERE id = $1`,
+			userID, mfaMaxFailures, mfaLockout.Seconds()); err != nil {
+			return err
+		}
+		if err := tx.Commit(context.Background()); err != nil {
+			return err
+		}
+		return ErrInvalidMFACode
+	}
+
+	if _, err := tx.Exec(context.Background(),
+		`UPDATE users SET mfa_failures = 0, mfa_locked_until = NULL WHERE id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit(context.Background())
+}
+
+// DisableTOTP turns off two-factor authentication after the user re-authenticates
+// with their password and a current code (or a recovery code)
+//
+// Returns:
+// - ErrWrongPassword, ErrMFANotEnabled, ErrMFALocked, ErrInvalidMFACode or database error
+//
+// Usage: Called by POST /me/2fa/disable endpoint
+func DisableTOTP(userID, password, code string) error {
+	var hash string
+	err := db.Pool.QueryRow(context.Background(),
+		`SELECT password_hash FROM users WHERE id = $1`, userID,
+	).Scan(&hash)
+	if err != nil {
+		return err
+	}
+	if !utils.CheckPassword(password, hash) {
+		return ErrWrongPassword
+	}
+
+	if err := VerifyMFACode(userID, code); err != nil {
+		return err
+	}
+
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	if _, err := tx.Exec(context.Background(),
+		`UPDATE users SET totp_enabled = FALSE, totp_secret = NULL, totp_pending_secret = NULL, totp_last_step = NULL
+		 WHERE id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(context.Background(),
+		`DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit(context.Background())
+}
+
+// IsTOTPEnabled reports whether logging in as the user needs a second factor.
+//
+// Usage: Called by the login handlers after the first factor succeeds
+func IsTOTPEnabled(userID string) (bool, error) {
+	var enabled bool
+	err := db.Pool.QueryRow(context.Background(),
+		"SELECT EXISTS(SELECT 1 FROM users WHERE id=$1 AND totp_enabled)", userID,
+	).Scan(&enabled)
+	return enabled, err
+}
@@ -0,0 +1,447 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrUnknownOIDCProvider   = errors.New("unknown login provider")
+	ErrOIDCState             = errors.New("invalid or expired login state; start the login again")
+	ErrOIDCRejected          = errors.New("login provider rejected the sign-in")
+	ErrOIDCUnavailable       = errors.New("login provider unreachable")
+	ErrOIDCNoEmail           = errors.New("login provider did not share an email address")
+	ErrOIDCEmailUnverified   = errors.New("an account already uses this email, but the provider hasn't verified it; sign in with your password instead")
+	ErrOIDCAccountUnverified = errors.New("an account already uses this email, but its owner never verified it; verify the email or reset the password (POST /auth/forgot-password), then sign in with the provider again")
+)
+
+// OIDCStateTTL is how long a login started with StartOIDCLogin can be completed.
+const OIDCStateTTL = 10 * time.Minute
+
+// OIDCProvider describes an OpenID Connect provider used for the authorization code flow with PKCE.
+//
+// Fields:
+// - Name: Provider key used in URLs and user_identities.provider (e.g., "google")
+// - ClientID / ClientSecret: OAuth client registered with the provider
+// - AuthURL: Authorization endpoint the browser is sent to
+// - TokenURL: Token endpoint the code is exchanged at
+// - UserInfoURL: UserInfo endpoint returning sub, email, email_verified and name
+// - Scopes: Requested scopes (must include "openid" and "email")
+// - Client: HTTP client for the token and userinfo calls
+//
+// Identity is read from the UserInfo endpoint with the access token received directly
+// from the token endpoint over TLS, so the ID token signature doesn't need checking.
+type OIDCProvider struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	Scopes       []string
+	Client       *http.Client
+}
+
+// oidcPresets holds the endpoints of the built-in providers.
+var oidcPresets = map[string]OIDCProvider{
+	"google": {
+		Name:        "google",
+		AuthURL:     "https://accounts.google.com/o/oauth2/v2/auth",
+		TokenURL:    "https://oauth2.googleapis.com/token",
+		UserInfoURL: "https://openidconnect.googleapis.com/v1/userinfo",
+		Scopes:      []string{"openid", "email", "profile"},
+	},
+	"linkedin": {
+		Name:        "linkedin",
+		AuthURL:     "https://www.linkedin.com/oauth/v2/authorization",
+		TokenURL:    "https://www.linkedin.com/oauth/v2/accessToken",
+		UserInfoURL: "https://api.linkedin.com/v2/userinfo",
+		Scopes:      []string{"openid", "email", "profile"},
+	},
+}
+
+// NewOIDCProvider builds a provider from a preset ("google", "linkedin") or from scratch
+// for any other name. Non-empty URL arguments override the preset's endpoints, which is
+// how the flow is pointed at a local mock OIDC server.
+//
+// Returns an error if the provider ends up without a client ID or an endpoint.
+//
+// Usage: Called from main for each name in OIDC_PROVIDERS
+func NewOIDCProvider(name, clientID, clientSecret, authURL, tokenURL, userInfoURL string) (*OIDCProvider, error) {
+	p := oidcPresets[name]
+	p.Name = name
+	p.ClientID = clientID
+	p.ClientSecret = clientSecret
+	if authURL != "" {
+		p.AuthURL = authURL
+	}
+	if tokenURL != "" {
+		p.TokenURL = tokenURL
+	}
+	if userInfoURL != "" {
+		p.UserInfoURL = userInfoURL
+	}
+	if len(p.Scopes) == 0 {
+		p.Scopes = []string{"openid", "email", "profile"}
+	}
+	p.Client = &http.Client{Timeout: 10 * time.Second}
+
+	if p.ClientID == "" || p.AuthURL == "" || p.TokenURL == "" || p.UserInfoURL == "" {
+		return nil, fmt.Errorf("oidc provider %q needs a client ID and auth, token and userinfo URLs", name)
+	}
+	return &p, nil
+}
+
+var (
+	oidcProvidersMu sync.RWMutex
+	oidcProviders   = map[string]*OIDCProvider{}
+)
+
+// RegisterOIDCProvider makes a provider available for login under p.Name.
+//
+// Usage: Called once per provider from main
+func RegisterOIDCProvider(p *OIDCProvider) {
+	oidcProvidersMu.Lock()
+	defer oidcProvidersMu.Unlock()
+	oidcProviders[p.Name] = p
+}
+
+// OIDCProviders returns the names of the registered providers, sorted.
+func OIDCProviders() []string {
+	oidcProvidersMu.RLock()
+	defer oidcProvidersMu.RUnlock()
+	names := make([]string, 0, len(oidcProviders))
+	for name := range oidcProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func oidcProviderFor(name string) (*OIDCProvider, error) {
+	oidcProvidersMu.RLock()
+	defer oidcProvidersMu.RUnlock()
+	p, ok := oidcProviders[name]
+	if !ok {
+		return nil, ErrUnknownOIDCProvider
+	}
+	return p, nil
+}
+
+// randomURLToken returns n random bytes, base64url encoded without padding.
+func randomURLToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// StartOIDCLogin begins an authorization code login with PKCE
+//
+// Process:
+// 1. Generate a random state and PKCE code verifier
+// 2. Store both with the provider and redirect URI (oidc_states, valid OIDCStateTTL)
+// 3. Build the provider's authorization URL with the S256 code challenge
+//
+// Parameters:
+// - provider: Registered provider name
+// - redirectURI: Where the provider sends the browser back with ?code=&state= (OIDC_REDIRECT_URL)
+//
+// Returns:
+// - authorizationURL: Send the browser here
+// - state: Also embedded in the URL; returned for clients that want to keep it
+// - ErrUnknownOIDCProvider or database error
+//
+// Usage: Called by GET /auth/oidc/:provider
+func StartOIDCLogin(provider, redirectURI string) (string, string, error) {
+	p, err := oidcProviderFor(provider)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := randomURLToken(24)
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := randomURLToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	_, err = db.Pool.Exec(context.Background(),
+		`INSERT INTO oidc_states (state, provider, code_verifier, redirect_uri, expires_at, created_at)
+		 VALUES ($1, $2, $3, $4, NOW() + make_interval(secs => $5), NOW())`,
+		state, p.Name, verifier, redirectURI, OIDCStateTTL.Seconds())
+	if err != nil {
+		return "", "", err
+	}
+
+	// Opportunistically drop abandoned logins so the table stays small
+	_, _ = db.Pool.Exec(context.Background(), `DELETE FROM oidc_states WHERE expires_at < NOW()`)
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.ClientID)
+	q.Set("redirect_uri", redirectURI)
+	q.Set("scope", strings.Join(p.Scopes, " "))
+	q.Set("state", state)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(p.AuthURL, "?") {
+		sep = "&"
+	}
+	return p.AuthURL + sep + q.Encode(), state, nil
+}
+
+// oidcUserInfo holds the UserInfo claims used to find or create the account.
+type oidcUserInfo struct {
+	Subject       string          `json:"sub"`
+	Email         string          `json:"email"`
+	EmailVerified json.RawMessage `json:"email_verified"`
+	Name          string          `json:"name"`
+}
+
+// emailVerified accepts both true and "true"; some providers send the claim as a string.
+func (u oidcUserInfo) emailVerified() bool {
+	v := strings.Trim(string(u.EmailVerified), `"`)
+	return v == "true"
+}
+
+// exchangeCode trades an authorization code for an access token at the token endpoint.
+func (p *OIDCProvider) exchangeCode(ctx context.Context, code, redirectURI, verifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("client_id", p.ClientID)
+	form.Set("code_verifier", verifier)
+	if p.ClientSecret != "" {
+		form.Set("client_secret", p.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrOIDCUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return "", fmt.Errorf("%w: token endpoint returned HTTP %d", ErrOIDCUnavailable, resp.StatusCode)
+	}
+
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&tok); err != nil {
+		return "", fmt.Errorf("%w: token response: %v", ErrOIDCUnavailable, err)
+	}
+	if resp.StatusCode != http.StatusOK || tok.AccessToken == "" {
+		return "", fmt.Errorf("%w: %s", ErrOIDCRejected, tok.Error)
+	}
+	return tok.AccessToken, nil
+}
+
+// userInfo fetches the signed-in user's claims with an access token.
+func (p *OIDCProvider) userInfo(ctx context.Context, accessToken string) (*oidcUserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: userinfo endpoint returned HTTP %d", ErrOIDCUnavailable, resp.StatusCode)
+	}
+
+	var info oidcUserInfo
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&info); err != nil {
+		return nil, fmt.Errorf("%w: userinfo response: %v", ErrOIDCUnavailable, err)
+	}
+	if info.Subject == "" {
+		return nil, fmt.Errorf("%w: userinfo has no subject", ErrOIDCRejected)
+	}
+	return &info, nil
+}
+
+// CompleteOIDCLogin finishes a login started with StartOIDCLogin and returns the account to sign in
+//
+// Process:
+// 1. Consume the state (single use, not expired) to get the provider, redirect URI and code verifier
+// 2. Exchange the code (with the verifier) for an access token and fetch the UserInfo claims
+// 3. Find the account:
+// - a user already linked to this provider identity (user_identities), otherwise
+// - the user with the same email, if both the provider and the account verified it (the identity is linked), otherwise
+// - a new account created from the claims (no usable password; email verified if the provider says so)
+//
+// Parameters:
+// - code / state: Query parameters the provider redirected back with
+//
+// Returns:
+// - User ID (UUID string)
+// - ErrOIDCState, ErrOIDCRejected (wrapped), ErrOIDCUnavailable (wrapped), ErrOIDCNoEmail, ErrOIDCEmailUnverified, ErrOIDCAccountUnverified
+// - Other error if the database operation fails
+//
+// Usage: Called by POST /auth/oidc/callback
+func CompleteOIDCLogin(ctx context.Context, code, state string) (string, error) {
+	if code == "" || state == "" {
+		return "", ErrOIDCState
+	}
+
+	var providerName, verifier, redirectURI string
+	err := db.Pool.QueryRow(ctx,
+		`DELETE FROM oidc_states WHERE state = $1 AND expires_at > NOW()
+		 RETURNING provider, code_verifier, redirect_uri`,
+		state,
+	).Scan(&providerName, &verifier, &redirectURI)
+	if err == pgx.ErrNoRows {
+		return "", ErrOIDCState
+	}
+	if err != nil {
+		return "", err
+	}
+	p, err := oidcProviderFor(providerName)
+	if err != nil {
+		return "", err
+	}
+
+	accessToken, err := p.exchangeCode(ctx, code, redirectURI, verifier)
+	if err != nil {
+		return "", err
+	}
+	info, err := p.userInfo(ctx, accessToken)
+	if err != nil {
+		return "", err
+	}
+
+	return resolveOIDCUser(ctx, p.Name, info)
+}
+
+// resolveOIDCUser finds, links or creates the account for a provider identity.
+func resolveOIDCUser(ctx context.Context, provider string, info *oidcUserInfo) (string, error) {
+	userID, err := resolveLinkedIdentity(ctx, provider, info.Subject)
+	if err == nil {
+		return userID, nil
+	}
+	if err != pgx.ErrNoRows {
+		return "", err
+	}
+
+	if info.Email == "" {
+		return "", ErrOIDCNoEmail
+	}
+	verified := info.emailVerified()
+
+	tx, err := db.Pool.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		existing      uuid.UUID
+		localVerified bool
+	)
+	err = tx.QueryRow(ctx,
+		`SELECT id, email_verified FROM users WHERE lower(email) = lower($1) FOR UPDATE`, info.Email,
+	).Scan(&existing, &localVerified)
+	switch {
+	case err == nil:
+		// Only link on an email the provider vouches for, or anyone could claim an account
+		if !verified {
+			return "", ErrOIDCEmailUnverified
+		}
+		// Nor to an account that never proved it owns the address: whoever registered it
+		// may not be the inbox owner and would keep their password on the linked account
+		if !localVerified {
+			return "", ErrOIDCAccountUnverified
+		}
+	case err == pgx.ErrNoRows:
+		// New account: a random password nobody knows; the user can set one via forgot-password
+		random, err := randomURLToken(32)
+		if err != nil {
+			return "", err
+		}
+		hash, err := utils.HashPassword(random)
+		if err != nil {
+			return "", err
+		}
+		name := info.Name
+		if name == "" {
+			name = strings.SplitN(info.Email, "@", 2)[0]
+		}
+		existing = uuid.New()
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO users (id, name, email, password_hash, email_verified, email_verified_at, created_at)
+			 VALUES ($1, $2, $3, $4, $5, CASE WHEN $5 THEN NOW() END, NOW())`,
+			existing, name, info.Email, hash, verified); err != nil {
+			return "", err
+		}
+		if err := grantRole(ctx, tx, existing, RoleCandidate, nil); err != nil {
+			return "", err
+		}
+	default:
+		return "", err
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO user_identities (id, user_id, provider, subject, email, created_at)
+		 VALUES ($1, $2, $3, $4, $5, NOW())`,
+		uuid.New(), existing, provider, info.Subject, info.Email)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			// A concurrent callback linked the identity first; use its account
+			return resolveLinkedIdentity(ctx, provider, info.Subject)
+		}
+		return "", err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return existing.String(), nil
+}
+
+// resolveLinkedIdentity returns the account an identity is linked to.
+func resolveLinkedIdentity(ctx context.Context, provider, subject string) (string, error) {
+	var userID string
+	err := db.Pool.QueryRow(ctx,
+		`SELECT user_id::text FROM user_identities WHERE provider = $1 AND subject = $2`,
+		provider, subject,
+	).Scan(&userID)
+	return userID, err
+}
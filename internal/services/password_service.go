@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+	ErrWrongPassword     = errors.New("current password is incorrect")
+	ErrInvalidPassword   = errors.New("password must be 8 to 72 characters")
+)
+
+// Password length limits for reset and change; bcrypt ignores input past 72 bytes.
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// passwordResetCooldown silently skips reset emails requested again within this window.
+const passwordResetCooldown = time.Minute
+
+// validatePassword enforces the password length limits.
+func validatePassword(password string) error {
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return ErrInvalidPassword
+	}
+	return nil
+}
+
+// RequestPasswordReset emails a single-use password reset link if the address belongs to an account
+//
+// Process:
+// 1. Look up the user by email; unknown addresses return nil so callers can't probe for accounts
+// 2. Skip if a reset link was sent to the user within the last minute
+// 3. Store the SHA-256 of a random token with its expiry (password_reset_tokens)
+// 4. Email resetURL?token=<token> with the configured Mailer
+//
+// Parameters:
+// - email: Address from the forgot-password form
+// - resetURL: Frontend page that posts the token and new password to POST /auth/reset-password (PASSWORD_RESET_URL)
+// - ttl: Link lifetime (PASSWORD_RESET_TTL_MINUTES)
+//
+// Returns:
+// - nil when the address is unknown, throttled or the email was sent
+// - ErrMailerUnavailable, mailer or database error otherwise
+//
+// Usage: Called by POST /auth/forgot-password endpoint
+func RequestPasswordReset(email, resetURL string, ttl time.Duration) error {
+	var (
+		userID uuid.UUID
+		name   string
+		recent bool
+	)
+	err := db.Pool.QueryRow(context.Background(),
+		`SELECT u.id, u.name, EXISTS(
+		     SELECT 1 FROM password_reset_tokens t
+		     WHERE t.user_id = u.id AND t.created_at > NOW() - make_interval(secs => $2))
+		 FROM users u WHERE u.email = $1`,
+		email, passwordResetCooldown.Seconds(),
+	).Scan(&userID, &name, &recent)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if recent {
+		return nil
+	}
+
+	// Reset tokens are random, single-use secrets like refresh tokens; only the hash is stored
+	token, hash, err := newRefreshToken()
+	if err != nil {
+		return err
+	}
+	tokenID := uuid.New()
+	_, err = db.Pool.Exec(context.Background(),
+		`INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at)
+		 VALUES ($1, $2, $3, NOW() + make_interval(secs => $4), NOW())`,
+		tokenID, userID, hash, ttl.Seconds())
+	if err != nil {
+		return err
+	}
+
+	link := resetURL + "?token=" + url.QueryEscape(token)
+	err = sendMail(context.Background(), Email{
+		To:      email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to reset the password of your account. To choose a new one, open:\n\n%s\n\nThe link works once and expires in %s. If it wasn't you, ignore this email; your password stays the same.\n",
+			name, link, ttl),
+	})
+	if err != nil {
+		// Don't let an undelivered token block a retry
+		_, _ = db.Pool.Exec(context.Background(), `DELETE FROM password_reset_tokens WHERE id = $1`, tokenID)
+		return err
+	}
+
+	// Opportunistically drop old tokens so the table stays small
+	_, _ = db.Pool.Exec(context.Background(),
+		`DELETE FROM password_reset_tokens WHERE expires_at < NOW() - INTERVAL '1 day'`)
+
+	return nil
+}
+
+// ResetPassword sets a new password using a token from RequestPasswordReset
+//
+// Process:
+// 1. Validate the new password length
+// 2. Find the unused, unexpired token by hash and lock it
+// 3. Store the new bcrypt hash and mark the email verified (the link proved access to the inbox)
+// 4. Use up this and every other outstanding reset token of the user
+// 5. Revoke all of the user's sessions (reason "password_reset")
+//
+// Returns:
+// - ErrInvalidPassword, ErrInvalidResetToken or database error
+//
+// Usage: Called by POST /auth/reset-password endpoint
+func ResetPassword(token, newPassword string) error {
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+	if token == "" {
+		return ErrInvalidResetToken
+	}
+
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	var userID uuid.UUID
+	err = tx.QueryRow(context.Background(),
+		`SELECT user_id FROM password_reset_tokens
+		 WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		 FOR UPDATE`,
+		hashRefreshToken(token),
+	).Scan(&userID)
+	if err == pgx.ErrNoRows {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	hash, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(context.Background(),
+		`UPDATE users SET password_hash = $2, email_verified = TRUE,
+		     email_verified_at = COALESCE(email_verified_at, NOW())
+		 WHERE id = $1`,
+		userID, hash); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(context.Background(),
+		`UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`,
+		userID); err != nil {
+		return err
+	}
+	if _, err := revokeUserSessions(context.Background(), tx, userID.String(), SessionRevokedPasswordReset); err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+// ChangePassword replaces the password of a signed-in user
+//
+// Process:
+// 1. Validate the new password length
+// 2. Check the current password
+// 3. Store the new bcrypt hash and revoke all of the user's sessions (reason "password_change")
+//
+// The caller should start a fresh session for the user afterwards.
+//
+// Returns:
+// - ErrInvalidPassword, ErrWrongPassword or database error
+//
+// Usage: Called by PUT /me/password endpoint
+func ChangePassword(userID, currentPassword, newPassword string) error {
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	var current string
+	err = tx.QueryRow(context.Background(),
+		`SELECT password_hash FROM users WHERE id = $1 FOR UPDATE`, userID,
+	).Scan(&current)
+	if err != nil {
+		return err
+	}
+	if !utils.CheckPassword(currentPassword, current) {
+		return ErrWrongPassword
+	}
+
+	hash, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(context.Background(),
+		`UPDATE users SET password_hash = $2 WHERE id = $1`, userID, hash); err != nil {
+		return err
+	}
+	if _, err := revokeUserSessions(context.Background(), tx, userID, SessionRevokedPasswordChange); err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
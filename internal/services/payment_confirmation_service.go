@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// pendingPaymentBatch caps how many pending payments one confirmation round checks.
+const pendingPaymentBatch = 100
+
+// pendingPayment is a payments ledger row waiting for confirmations.
+type pendingPayment struct {
+	ID            uuid.UUID
+	Method        string
+	TxHash        string
+	JobID         *uuid.UUID
+	UserID        *uuid.UUID
+	Wallet        string
+	ConfirmStatus string
+	Amount        *big.Int
+	DurationDays  int
+	TimedOut      bool
+}
+
+// ConfirmPendingPayments checks every pending job payment once
+//
+// Process:
+// 1. Load pending payments, oldest first
+// 2. Verify each with its payment method against the payer wallet and amount recorded at submission, requiring `required` confirmations
+// 3. Confirmed: mark the payment confirmed and move the job to its requested status (published jobs get expires_at = now + the plan's duration_days)
+// 4. Rejected: mark the payment failed and the job payment_failed
+// 5. Still pending: record the confirmations seen, or fail it once it is older than timeout
+//
+// Parameters:
+// - ctx: Context for database and RPC calls
+// - required: Confirmations needed (PAYMENT_CONFIRMATIONS)
+// - timeout: How long a payment may stay pending before the job is failed
+//
+// Returns:
+// - Number of payments confirmed and failed in this round
+// - ErrPaymentUnavailable (wrapped) if a payment provider can't be reached; remaining payments are retried next round
+// - Database error
+//
+// Usage: Called periodically by StartPaymentConfirmationWorker
+func ConfirmPendingPayments(ctx context.Context, required int, timeout time.Duration) (int, int, error) {
+	rows, err := db.Pool.Query(ctx,
+		`SELECT p.id, p.method, p.tx_hash, p.job_id, p.user_id, COALESCE(p.payer_wallet, ''), COALESCE(p.confirm_status, $1),
+		        p.amount_wei::text, COALESCE(jp.duration_days, 0),
+		        p.created_at < NOW() - make_interval(secs => $2)
+		 FROM payments p
+		 LEFT JOIN jobs j ON j.id = p.job_id
+		 LEFT JOIN job_plans jp ON jp.slug = j.plan
+		 WHERE p.status = $3
+		 ORDER BY p.created_at
+		 LIMIT $4`,
+		JobStatusPublished, timeout.Seconds(), PaymentStatusPending, pendingPaymentBatch)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var pending []pendingPayment
+	for rows.Next() {
+		var (
+			p      pendingPayment
+			amount *string
+		)
+		if err := rows.Scan(&p.ID, &p.Method, &p.TxHash, &p.JobID, &p.UserID, &p.Wallet, &p.ConfirmStatus, &amount, &p.DurationDays, &p.TimedOut); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		if amount != nil {
+			p.Amount, _ = new(big.Int).SetString(*amount, 10)
+		}
+		pending = append(pending, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	confirmed, failed := 0, 0
+	for _, p := range pending {
+		if p.JobID == nil || p.UserID == nil {
+			if err := failPayment(ctx, p, "job or paying user was deleted"); err != nil {
+				return confirmed, failed, err
+			}
+			failed++
+			continue
+		}
+		// Rows from before the ledger stored amounts can't be checked against what was owed
+		if p.Amount == nil {
+			if err := failPayment(ctx, p, "payment has no recorded amount"); err != nil {
+				return confirmed, failed, err
+			}
+			failed++
+			continue
+		}
+
+		claim := PaymentClaim{Reference: p.TxHash, UserID: p.UserID.String(), Wallet: p.Wallet, Amount: p.Amount}
+		confirmations, err := verifyPayment(ctx, p.Method, claim, required)
+		switch {
+		case err == nil:
+			if err := confirmPayment(ctx, p, confirmations); err != nil {
+				return confirmed, failed, err
+			}
+			confirmed++
+		case errors.Is(err, ErrPaymentUnavailable):
+			return confirmed, failed, err
+		case errors.Is(err, ErrPaymentPending), errors.Is(err, ErrPaymentNotFound):
+			if p.TimedOut {
+				reason := fmt.Sprintf("not confirmed within %s (%d of %d confirmations)", timeout, confirmations, required)
+				if err := failPayment(ctx, p, reason); err != nil {
+					return confirmed, failed, err
+				}
+				failed++
+				continue
+			}
+			_, err := db.Pool.Exec(ctx,
+				`UPDATE payments SET confirmations = $1, checked_at = NOW() WHERE id = $2`,
+				confirmations, p.ID)
+			if err != nil {
+				return confirmed, failed, err
+			}
+		default:
+			if err := failPayment(ctx, p, err.Error()); err != nil {
+				return confirmed, failed, err
+			}
+			failed++
+		}
+	}
+
+	return confirmed, failed, nil
+}
+
+// confirmPayment marks a pending payment confirmed and releases its job.
+// The job is only moved if it is still pending_payment (the owner may have closed it meanwhile).
+func confirmPayment(ctx context.Context, p pendingPayment, confirmations int) error {
+	tx, err := db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx,
+		`UPDATE payments SET status = $1, confirmations = $2, checked_at = NOW(), confirmed_at = NOW()
+		 WHERE id = $3`,
+		PaymentStatusConfirmed, confirmations, p.ID)
+	if err != nil {
+		return err
+	}
+
+	var expiresAt *time.Time
+	if p.ConfirmStatus == JobStatusPublished {
+		t := time.Now().AddDate(0, 0, p.DurationDays)
+		expiresAt = &t
+	}
+	tag, err := tx.Exec(ctx,
+		`UPDATE jobs SET status = $1, expires_at = $2 WHERE id = $3 AND status = $4`,
+		p.ConfirmStatus, expiresAt, *p.JobID, JobStatusPendingPayment)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() > 0 && p.ConfirmStatus == JobStatusPublished {
+		notifyJobAlerts(p.JobID.String())
+	}
+	return nil
+}
+
+// failPayment marks a pending payment failed and its job payment_failed.
+// The row stays for the job's payment state, but a failed payment no longer consumes its
+// hash, so whoever really sent the transaction can still use it once it is mined.
+func failPayment(ctx context.Context, p pendingPayment, reason string) error {
+	tx, err := db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx,
+		`UPDATE payments SET status = $1, failure_reason = $2, checked_at = NOW() WHERE id = $3`,
+		PaymentStatusFailed, reason, p.ID)
+	if err != nil {
+		return err
+	}
+
+	if p.JobID != nil {
+		_, err = tx.Exec(ctx,
+			`UPDATE jobs SET status = $1 WHERE id = $2 AND status = $3`,
+			JobStatusPaymentFailed, *p.JobID, JobStatusPendingPayment)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// StartPaymentConfirmationWorker runs ConfirmPendingPayments every interval until ctx is cancelled.
+// Runs once immediately so payments confirmed while the server was down are picked up on startup.
+//
+// Usage: Started from main() as a background goroutine when a payment provider is configured
+func StartPaymentConfirmationWorker(ctx context.Context, interval time.Duration, required int, timeout time.Duration) {
+	check := func() {
+		confirmed, failed, err := ConfirmPendingPayments(ctx, required, timeout)
+		if err != nil {
+			log.Println("payment confirmation check failed:", err)
+		}
+		if confirmed > 0 || failed > 0 {
+			log.Printf("payment confirmation check: %d confirmed, %d failed", confirmed, failed)
+		}
+	}
+
+	go func() {
+		check()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				check()
+			}
+		}
+	}()
+}
+
+// GetJobPaymentState returns the confirmation state of a job's posting payment
+//
+// Parameters:
+// - jobIDStr: UUID string of the job
+// - userID: UUID string of the caller (the poster, or an owner/recruiter of the job's company)
+// - required: Confirmations needed (PAYMENT_CONFIRMATIONS), echoed in the result
+//
+// Returns:
+// - *models.PaymentState, or nil if the job has no ledger entry (e.g. posted before the ledger existed)
+// - ErrJobNotFound, ErrNotJobOwner, or database error
+//
+// Usage: Called by GET /jobs/:id to show the payment state to the job's managers
+func GetJobPaymentState(jobIDStr, userID string, required int) (*models.PaymentState, error) {
+	job, err := getOwnedJob(jobIDStr, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &models.PaymentState{RequiredConfirmations: required}
+	var failureReason *string
+	err = db.Pool.QueryRow(context.Background(),
+		`SELECT method, tx_hash, chain_id, status, confirmations, failure_reason, checked_at, confirmed_at
+		 FROM payments
+		 WHERE job_id = $1 AND purpose = $2
+		 ORDER BY created_at
+		 LIMIT 1`,
+		job.ID, PaymentPurposeJobPost,
+	).Scan(&state.Method, &state.TxHash, &state.ChainID, &state.Status, &state.Confirmations, &failureReason, &state.CheckedAt, &state.ConfirmedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	state.FailureReason = safeStr(failureReason)
+	return state, nil
+}
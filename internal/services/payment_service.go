@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrPaymentNotFound    = errors.New("payment transaction not found on chain")
+	ErrPaymentPending     = errors.New("payment transaction is not mined yet")
+	ErrPaymentRejected    = errors.New("payment transaction rejected")
+	ErrWalletRequired     = errors.New("verify your wallet with sign-in with ethereum (POST /auth/siwe/verify) before paying")
+	ErrPaymentUnavailable = errors.New("payment verification is temporarily unavailable")
+)
+
+// newPaymentClaim builds the claim that a payment of at least amount, referenced by ref, was made by userID.
+// Only a wallet proven via Sign-In with Ethereum is used as the payer's; EVM methods return
+// ErrWalletRequired without one. The claim is stored with the payment (see insertPayment), so a
+// pending payment is later re-verified against the same wallet and amount even if the user's
+// wallet or the plan price changes meanwhile.
+func newPaymentClaim(ref, userID string, amount *big.Int) (PaymentClaim, error) {
+	user, err := GetUserByID(userID)
+	if err != nil {
+		return PaymentClaim{}, err
+	}
+	claim := PaymentClaim{Reference: ref, UserID: userID, Amount: amount}
+	if user.WalletVerified {
+		claim.Wallet = user.WalletAddress
+	}
+	return claim, nil
+}
+
+// verifyPayment checks claim with the payment method's verifier and requires at least
+// minConfirmations confirmations. It is a no-op for evm_native when on-chain verification
+// is disabled (see paymentVerifierFor).
+//
+// Returns:
+// - confirmations seen so far (also set with ErrPaymentPending when too few)
+// - ErrPaymentPending if the payment isn't settled or has fewer than minConfirmations
+// - ErrUnknownPaymentMethod (wrapped) or other verification errors as returned by PaymentVerifier.Verify
+func verifyPayment(ctx context.Context, method string, claim PaymentClaim, minConfirmations int) (int, error) {
+	_, v, err := paymentVerifierFor(method)
+	if err != nil {
+		return 0, err
+	}
+	if v == nil {
+		return 0, nil
+	}
+
+	confirmations, err := v.Verify(ctx, claim)
+	if err != nil {
+		return confirmations, err
+	}
+	if confirmations < minConfirmations {
+		return confirmations, ErrPaymentPending
+	}
+	return confirmations, nil
+}
+
+// Payment ledger purposes (payments.purpose).
+const (
+	PaymentPurposeJobPost    = "job_post"
+	PaymentPurposeJobRenewal = "job_renewal"
+	PaymentPurposeCredits    = "credit_purchase"
+)
+
+// PaymentConsumedError is returned when a transaction hash is already in the payments ledger.
+// It matches ErrTxHashReused with errors.Is.
+//
+// Fields:
+// - ChainID / TxHash: The consumed payment
+// - JobID: Job the payment was used for (nil if that job was deleted)
+type PaymentConsumedError struct {
+	ChainID int64
+	TxHash  string
+	JobID   *uuid.UUID
+}
+
+func (e *PaymentConsumedError) Error() string {
+	return fmt.Sprintf("payment_tx_hash %s has already been used", e.TxHash)
+}
+
+// Is makes errors.Is(err, ErrTxHashReused) true for consumed payments.
+func (e *PaymentConsumedError) Is(target error) bool {
+	return target == ErrTxHashReused
+}
+
+// normalizeTxHash lowercases a transaction hash so case variants of the same
+// hash map to one ledger entry.
+func normalizeTxHash(txHash string) string {
+	return strings.ToLower(txHash)
+}
+
+// checkPaymentUnused returns a *PaymentConsumedError if the hash is already in the ledger
+// as a pending or confirmed payment; failed payments don't consume their hash.
+// Called before on-chain verification to avoid needless RPC calls; recordPayment
+// enforces the same rule atomically through the partial unique index.
+func checkPaymentUnused(ctx context.Context, chainID int64, txHash string) error {
+	var jobID *uuid.UUID
+	err := db.Pool.QueryRow(ctx,
+		"SELECT job_id FROM payments WHERE chain_id=$1 AND tx_hash=$2 AND status <> $3",
+		chainID, normalizeTxHash(txHash), PaymentStatusFailed,
+	).Scan(&jobID)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return &PaymentConsumedError{ChainID: chainID, TxHash: txHash, JobID: jobID}
+}
+
+// Payment ledger states (payments.status).
+const (
+	PaymentStatusPending   = "pending"
+	PaymentStatusConfirmed = "confirmed"
+	PaymentStatusFailed    = "failed"
+)
+
+// recordPayment adds a confirmed payment to the ledger inside tx, with the payment method that
+// verified it and the confirmations seen. A unique violation on (chain_id, tx_hash) among pending and
+// confirmed payments becomes a *PaymentConsumedError.
+func recordPayment(ctx context.Context, tx pgx.Tx, chainID int64, method string, claim PaymentClaim, jobID, userID uuid.UUID, purpose string, confirmations int) error {
+	_, err := insertPayment(ctx, tx, chainID, method, claim, &jobID, userID, purpose, PaymentStatusConfirmed, "", confirmations)
+	return err
+}
+
+// recordPendingPayment adds a payment still waiting for confirmations to the ledger inside tx.
+// confirmStatus is the job status applied once the confirmation worker confirms it. The worker
+// re-verifies with the same method against the wallet and amount stored from claim, so later
+// wallet or plan price changes don't affect it. Pending payments consume the hash like confirmed ones.
+func recordPendingPayment(ctx context.Context, tx pgx.Tx, chainID int64, method string, claim PaymentClaim, jobID, userID uuid.UUID, confirmStatus string, confirmations int) error {
+	_, err := insertPayment(ctx, tx, chainID, method, claim, &jobID, userID, PaymentPurposeJobPost, PaymentStatusPending, confirmStatus, confirmations)
+	return err
+}
+
+// insertPayment adds a ledger entry for claim inside tx and returns its ID: its reference as tx_hash,
+// the amount it had to cover (in the method's smallest unit) and the payer wallet it was verified
+// against. jobID is nil for payments that aren't tied to a job (credit purchases).
+func insertPayment(ctx context.Context, tx pgx.Tx, chainID int64, method string, claim PaymentClaim, jobID *uuid.UUID, userID uuid.UUID, purpose, status, confirmStatus string, confirmations int) (uuid.UUID, error) {
+	id := uuid.New()
+	_, err := tx.Exec(ctx,
+		`INSERT INTO payments (id, chain_id, method, tx_hash, job_id, user_id, purpose, amount_wei, payer_wallet, status, confirm_status,
+		                       confirmations, checked_at, confirmed_at, created_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8::numeric,$9,$10,$11,$12, NOW(), CASE WHEN $13::boolean THEN NOW() END, NOW())`,
+		id, chainID, method, normalizeTxHash(claim.Reference), jobID, userID, purpose, claim.Amount.String(), nullStr(claim.Wallet),
+		status, nullStr(confirmStatus), confirmations,
+		status == PaymentStatusConfirmed,
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return uuid.Nil, &PaymentConsumedError{ChainID: chainID, TxHash: claim.Reference}
+	}
+	return id, err
+}
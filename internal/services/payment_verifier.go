@@ -0,0 +1,521 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrUnknownPaymentMethod = errors.New("unsupported payment_method")
+
+// Payment methods (payment_method in POST /jobs and POST /jobs/:id/renew, payments.method).
+const (
+	PaymentMethodEVMNative = "evm_native"
+	PaymentMethodERC20     = "erc20"
+	PaymentMethodFake      = "fake"
+)
+
+// DefaultPaymentMethod is used when a request doesn't name a payment_method.
+const DefaultPaymentMethod = PaymentMethodEVMNative
+
+// PaymentClaim is a payment a PaymentVerifier is asked to check.
+//
+// Fields:
+// - Reference: What the client sent as payment_tx_hash (a transaction hash, or a provider's payment ID)
+// - UserID: UUID string of the paying user
+// - Wallet: The payer's wallet_address if verified with Sign-In with Ethereum, empty otherwise
+// - Amount: Minimum amount in the method's smallest unit (see planPrice)
+type PaymentClaim struct {
+	Reference string
+	UserID    string
+	Wallet    string
+	Amount    *big.Int
+}
+
+// PaymentVerifier checks job payments for one payment method.
+// Job code only talks to this interface; a new provider is added by implementing it
+// and registering it under its method name with RegisterPaymentVerifier.
+type PaymentVerifier interface {
+	// ValidateReference checks the reference format before anything else is done.
+	// Returns ErrInvalidTxHash if it is malformed.
+	ValidateReference(ref string) error
+
+	// Verify checks that the claimed payment was made.
+	//
+	// Returns:
+	// - confirmations: How settled the payment is (blocks for on-chain methods); compared with the required confirmations
+	// - ErrPaymentNotFound if the payment is unknown
+	// - ErrPaymentPending if it isn't settled yet; only after everything already knowable about it (payer, recipient, amount) was checked, because pending payments park jobs
+	// - ErrPaymentRejected (wrapped with the reason) if it doesn't pay for the claim
+	// - ErrWalletRequired if the method needs a verified wallet and Wallet is empty
+	// - ErrPaymentUnavailable (wrapped) if the provider can't be reached
+	Verify(ctx context.Context, claim PaymentClaim) (int, error)
+}
+
+var (
+	paymentVerifiersMu sync.RWMutex
+	paymentVerifiers   = map[string]PaymentVerifier{}
+)
+
+// RegisterPaymentVerifier makes a payment method available to POST /jobs and renewals.
+// Passing nil removes the method.
+//
+// Usage: Called from main for each configured provider (ETH_RPC_URL, ERC20_TOKEN_ADDRESS, PAYMENT_FAKE)
+func RegisterPaymentVerifier(method string, v PaymentVerifier) {
+	paymentVerifiersMu.Lock()
+	defer paymentVerifiersMu.Unlock()
+	if v == nil {
+		delete(paymentVerifiers, method)
+		return
+	}
+	paymentVerifiers[method] = v
+}
+
+// PaymentMethods lists the registered payment methods, sorted by name.
+func PaymentMethods() []string {
+	paymentVerifiersMu.RLock()
+	defer paymentVerifiersMu.RUnlock()
+	methods := make([]string, 0, len(paymentVerifiers))
+	for m := range paymentVerifiers {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// paymentVerifierFor resolves a payment_method to its verifier.
+//
+// An empty method means DefaultPaymentMethod. When evm_native has no verifier
+// (ETH_RPC_URL unset) it resolves to nil: only the hash format is checked.
+//
+// Returns:
+// - The method name and its verifier (nil for unverified evm_native)
+// - ErrUnknownPaymentMethod if the method isn't registered
+func paymentVerifierFor(method string) (string, PaymentVerifier, error) {
+	if method == "" {
+		method = DefaultPaymentMethod
+	}
+	paymentVerifiersMu.RLock()
+	v, ok := paymentVerifiers[method]
+	paymentVerifiersMu.RUnlock()
+	if ok {
+		return method, v, nil
+	}
+	if method == PaymentMethodEVMNative {
+		return method, nil, nil
+	}
+	return "", nil, fmt.Errorf("%w: %q", ErrUnknownPaymentMethod, method)
+}
+
+// validatePaymentReference checks a payment reference with the method's verifier,
+// falling back to the transaction hash format when there is none.
+func validatePaymentReference(v PaymentVerifier, ref string) error {
+	if v == nil {
+		return validateTxHash(ref)
+	}
+	return v.ValidateReference(ref)
+}
+
+// ethRPC is a minimal Ethereum JSON-RPC client shared by the EVM verifiers.
+type ethRPC struct {
+	RPCURL string
+	Client *http.Client
+}
+
+// ethTransaction holds the eth_getTransactionByHash fields the verifiers need.
+type ethTransaction struct {
+	From        string  `json:"from"`
+	To          *string `json:"to"`
+	Value       string  `json:"value"`
+	Input       string  `json:"input"`
+	BlockNumber *string `json:"blockNumber"`
+}
+
+// ethLog holds the fields of a receipt log entry.
+type ethLog struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+// ethReceipt holds the eth_getTransactionReceipt fields the verifiers need.
+type ethReceipt struct {
+	Status      string   `json:"status"`
+	BlockNumber string   `json:"blockNumber"`
+	Logs        []ethLog `json:"logs"`
+}
+
+// call performs a JSON-RPC request and decodes the result into out.
+// found is false when the node returned a null result.
+func (r ethRPC) call(ctx context.Context, method string, params []interface{}, out interface{}) (bool, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.RPCURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrPaymentUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("%w: %s returned HTTP %d", ErrPaymentUnavailable, method, resp.StatusCode)
+	}
+
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return false, fmt.Errorf("%w: %s: %v", ErrPaymentUnavailable, method, err)
+	}
+	if rpcResp.Error != nil {
+		return false, fmt.Errorf("%w: %s: %s", ErrPaymentUnavailable, method, rpcResp.Error.Message)
+	}
+	if len(rpcResp.Result) == 0 || string(rpcResp.Result) == "null" {
+		return false, nil
+	}
+	if err := json.Unmarshal(rpcResp.Result, out); err != nil {
+		return false, fmt.Errorf("%w: %s: %v", ErrPaymentUnavailable, method, err)
+	}
+	return true, nil
+}
+
+// confirmations returns the number of blocks since (and including) txBlock.
+func (r ethRPC) confirmations(ctx context.Context, txBlock string) (int, error) {
+	var head string
+	if _, err := r.call(ctx, "eth_blockNumber", []interface{}{}, &head); err != nil {
+		return 0, err
+	}
+	headNum, ok1 := parseHexBig(head)
+	txNum, ok2 := parseHexBig(txBlock)
+	if !ok1 || !ok2 {
+		return 0, fmt.Errorf("%w: invalid block number", ErrPaymentUnavailable)
+	}
+	confirmations := new(big.Int).Sub(headNum, txNum).Int64() + 1
+	if confirmations < 1 {
+		// The node's head can briefly lag behind the block it served the transaction from
+		confirmations = 1
+	}
+	return int(confirmations), nil
+}
+
+// parseHexBig parses a 0x-prefixed JSON-RPC quantity.
+func parseHexBig(s string) (*big.Int, bool) {
+	if !strings.HasPrefix(s, "0x") {
+		return nil, false
+	}
+	return new(big.Int).SetString(s[2:], 16)
+}
+
+// EthPaymentVerifier checks native-currency (ETH) payments against an Ethereum JSON-RPC node
+// (payment_method "evm_native")
+//
+// A payment is accepted when the transaction:
+// 1. Exists and is mined, with a successful receipt (status 0x1)
+// 2. Was sent to AdminWallet
+// 3. Transfers at least the claimed amount in wei (the plan's price_wei)
+// 4. Was sent from the paying user's verified wallet_address
+//
+// Checks 2-4 also run on transactions still in the mempool, so only a transaction that
+// already pays for the claim is reported as ErrPaymentPending (and parks the job).
+//
+// Fields:
+// - RPCURL: JSON-RPC endpoint (Sepolia provider, local anvil/hardhat node, or an httptest stub)
+// - AdminWallet: Platform wallet receiving payments (0x-prefixed address)
+// - Client: HTTP client used for RPC calls
+type EthPaymentVerifier struct {
+	ethRPC
+	AdminWallet string
+}
+
+// NewEthPaymentVerifier creates a verifier with a 10 second RPC timeout.
+func NewEthPaymentVerifier(rpcURL, adminWallet string) *EthPaymentVerifier {
+	return &EthPaymentVerifier{
+		ethRPC:      ethRPC{RPCURL: rpcURL, Client: &http.Client{Timeout: 10 * time.Second}},
+		AdminWallet: adminWallet,
+	}
+}
+
+// ValidateReference requires a transaction hash ("0x" + 64 hex characters).
+func (v *EthPaymentVerifier) ValidateReference(ref string) error {
+	return validateTxHash(ref)
+}
+
+// Verify checks that the claimed transaction pays at least claim.Amount wei from claim.Wallet.
+// Confirmations are blocks since (and including) the one the transaction was mined in.
+func (v *EthPaymentVerifier) Verify(ctx context.Context, claim PaymentClaim) (int, error) {
+	if claim.Wallet == "" {
+		return 0, ErrWalletRequired
+	}
+
+	var tx ethTransaction
+	found, err := v.call(ctx, "eth_getTransactionByHash", []interface{}{claim.Reference}, &tx)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, ErrPaymentNotFound
+	}
+
+	// Sender, recipient and value are known before the transaction is mined; check them
+	// first so nobody can park a job on someone else's broadcast transaction
+	if tx.To == nil || !strings.EqualFold(*tx.To, v.AdminWallet) {
+		return 0, fmt.Errorf("%w: not sent to the platform wallet", ErrPaymentRejected)
+	}
+	value, ok := parseHexBig(tx.Value)
+	if !ok {
+		return 0, fmt.Errorf("%w: invalid transaction value", ErrPaymentUnavailable)
+	}
+	if value.Cmp(claim.Amount) < 0 {
+		return 0, fmt.Errorf("%w: value %s wei is below the price of %s wei", ErrPaymentRejected, value, claim.Amount)
+	}
+	if !strings.EqualFold(tx.From, claim.Wallet) {
+		return 0, fmt.Errorf("%w: not sent from your wallet_address", ErrPaymentRejected)
+	}
+	if tx.BlockNumber == nil {
+		return 0, ErrPaymentPending
+	}
+
+	var receipt ethReceipt
+	found, err = v.call(ctx, "eth_getTransactionReceipt", []interface{}{claim.Reference}, &receipt)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, ErrPaymentPending
+	}
+	if receipt.Status != "0x1" {
+		return 0, fmt.Errorf("%w: transaction reverted", ErrPaymentRejected)
+	}
+
+	return v.confirmations(ctx, *tx.BlockNumber)
+}
+
+// erc20TransferTopic is keccak256("Transfer(address,address,uint256)"), topic 0 of ERC-20 Transfer logs.
+const erc20TransferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// ERC20PaymentVerifier checks ERC-20 token payments (e.g. a USDC stablecoin) by decoding
+// the Transfer logs of the transaction receipt (payment_method "erc20")
+//
+// A payment is accepted when the transaction:
+// 1. Is mined with a successful receipt (status 0x1)
+// 2. Emitted Transfer events of TokenAddress from the user's verified wallet_address to AdminWallet
+// 3. Whose values add up to at least the claimed amount in the token's smallest unit (the plan's prices["erc20"])
+//
+// Transfer logs only exist once the transaction is mined, so an unmined transaction is
+// reported as ErrPaymentPending only if it is a direct transfer(AdminWallet, amount) call
+// on TokenAddress from the user's wallet paying at least the claimed amount.
+//
+// Fields:
+// - RPCURL / Client: JSON-RPC endpoint and HTTP client, as for EthPaymentVerifier
+// - AdminWallet: Platform wallet receiving payments
+// - TokenAddress: ERC-20 contract whose transfers count
+type ERC20PaymentVerifier struct {
+	ethRPC
+	AdminWallet  string
+	TokenAddress string
+}
+
+// NewERC20PaymentVerifier creates a verifier with a 10 second RPC timeout.
+func NewERC20PaymentVerifier(rpcURL, adminWallet, tokenAddress string) *ERC20PaymentVerifier {
+	return &ERC20PaymentVerifier{
+		ethRPC:       ethRPC{RPCURL: rpcURL, Client: &http.Client{Timeout: 10 * time.Second}},
+		AdminWallet:  adminWallet,
+		TokenAddress: tokenAddress,
+	}
+}
+
+// ValidateReference requires a transaction hash ("0x" + 64 hex characters).
+func (v *ERC20PaymentVerifier) ValidateReference(ref string) error {
+	return validateTxHash(ref)
+}
+
+// Verify checks that the claimed transaction moved at least claim.Amount tokens from claim.Wallet to AdminWallet.
+func (v *ERC20PaymentVerifier) Verify(ctx context.Context, claim PaymentClaim) (int, error) {
+	if claim.Wallet == "" {
+		return 0, ErrWalletRequired
+	}
+
+	var receipt ethReceipt
+	found, err := v.call(ctx, "eth_getTransactionReceipt", []interface{}{claim.Reference}, &receipt)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		// No receipt: either unknown or still in the mempool
+		var tx ethTransaction
+		found, err := v.call(ctx, "eth_getTransactionByHash", []interface{}{claim.Reference}, &tx)
+		if err != nil {
+			return 0, err
+		}
+		if !found {
+			return 0, ErrPaymentNotFound
+		}
+		if err := v.checkPendingTransfer(tx, claim); err != nil {
+			return 0, err
+		}
+		return 0, ErrPaymentPending
+	}
+	if receipt.Status != "0x1" {
+		return 0, fmt.Errorf("%w: transaction reverted", ErrPaymentRejected)
+	}
+
+	paid := new(big.Int)
+	for _, l := range receipt.Logs {
+		if !strings.EqualFold(l.Address, v.TokenAddress) || len(l.Topics) != 3 ||
+			!strings.EqualFold(l.Topics[0], erc20TransferTopic) {
+			continue
+		}
+		from, to := topicAddress(l.Topics[1]), topicAddress(l.Topics[2])
+		if !strings.EqualFold(from, claim.Wallet) || !strings.EqualFold(to, v.AdminWallet) {
+			continue
+		}
+		value, ok := parseHexBig(l.Data)
+		if !ok {
+			return 0, fmt.Errorf("%w: invalid Transfer value", ErrPaymentUnavailable)
+		}
+		paid.Add(paid, value)
+	}
+	if paid.Sign() == 0 {
+		return 0, fmt.Errorf("%w: no token transfer from your wallet_address to the platform wallet", ErrPaymentRejected)
+	}
+	if paid.Cmp(claim.Amount) < 0 {
+		return 0, fmt.Errorf("%w: transferred %s token units, below the price of %s", ErrPaymentRejected, paid, claim.Amount)
+	}
+
+	return v.confirmations(ctx, receipt.BlockNumber)
+}
+
+// erc20TransferSelector is the 4-byte selector of transfer(address,uint256).
+const erc20TransferSelector = "0xa9059cbb"
+
+// checkPendingTransfer checks an unmined transaction by decoding its call data, since
+// it has no Transfer logs yet. Returns ErrPaymentRejected (wrapped) unless it is a
+// direct token transfer of at least claim.Amount from claim.Wallet to AdminWallet.
+func (v *ERC20PaymentVerifier) checkPendingTransfer(tx ethTransaction, claim PaymentClaim) error {
+	if !strings.EqualFold(tx.From, claim.Wallet) {
+		return fmt.Errorf("%w: not sent from your wallet_address", ErrPaymentRejected)
+	}
+	input := strings.ToLower(tx.Input)
+	// selector + 32-byte recipient + 32-byte amount
+	if tx.To == nil || !strings.EqualFold(*tx.To, v.TokenAddress) ||
+		len(input) != len(erc20TransferSelector)+128 || !strings.HasPrefix(input, erc20TransferSelector) {
+		return fmt.Errorf("%w: unmined transaction is not a direct token transfer; send the hash again once it is mined", ErrPaymentRejected)
+	}
+	args := input[len(erc20TransferSelector):]
+	if !strings.EqualFold(topicAddress("0x"+args[:64]), v.AdminWallet) {
+		return fmt.Errorf("%w: not sent to the platform wallet", ErrPaymentRejected)
+	}
+	value, ok := parseHexBig("0x" + args[64:])
+	if !ok {
+		return fmt.Errorf("%w: invalid transfer amount", ErrPaymentRejected)
+	}
+	if value.Cmp(claim.Amount) < 0 {
+		return fmt.Errorf("%w: transferred %s token units, below the price of %s", ErrPaymentRejected, value, claim.Amount)
+	}
+	return nil
+}
+
+// topicAddress extracts the address from a 32-byte indexed log topic.
+func topicAddress(topic string) string {
+	if len(topic) != 66 {
+		return ""
+	}
+	return "0x" + topic[26:]
+}
+
+// FakePayment is a payment known to FakePaymentVerifier.
+//
+// Fields:
+// - Amount: Amount paid (nil pays any price)
+// - Wallet: Wallet the payment came from (empty matches any payer)
+// - Confirmations: Reported confirmations
+// - Err: Returned instead of checking the payment (e.g. ErrPaymentPending)
+type FakePayment struct {
+	Amount        *big.Int
+	Wallet        string
+	Confirmations int
+	Err           error
+}
+
+// FakePaymentVerifier is an in-memory payment provider for tests and local development
+// (payment_method "fake", enabled with PAYMENT_FAKE=true). Never enable it in production.
+//
+// References added with Add are checked against their FakePayment. Unknown references
+// are ErrPaymentNotFound, or with AcceptUnknown a settled payment of exactly the price.
+type FakePaymentVerifier struct {
+	AcceptUnknown bool
+
+	mu       sync.Mutex
+	payments map[string]FakePayment
+}
+
+// fakeSettledConfirmations is reported for settled fake payments; above any PAYMENT_CONFIRMATIONS.
+const fakeSettledConfirmations = 1000
+
+// NewFakePaymentVerifier creates an empty fake provider.
+func NewFakePaymentVerifier(acceptUnknown bool) *FakePaymentVerifier {
+	return &FakePaymentVerifier{AcceptUnknown: acceptUnknown, payments: map[string]FakePayment{}}
+}
+
+// Add registers (or replaces) a payment under ref. References are case-insensitive.
+func (f *FakePaymentVerifier) Add(ref string, p FakePayment) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.payments[strings.ToLower(ref)] = p
+}
+
+// ValidateReference accepts any non-empty reference of up to 128 characters.
+func (f *FakePaymentVerifier) ValidateReference(ref string) error {
+	if ref == "" || len(ref) > 128 {
+		return ErrInvalidTxHash
+	}
+	return nil
+}
+
+// Verify checks the claim against the registered payment.
+func (f *FakePaymentVerifier) Verify(_ context.Context, claim PaymentClaim) (int, error) {
+	f.mu.Lock()
+	p, ok := f.payments[strings.ToLower(claim.Reference)]
+	f.mu.Unlock()
+	if !ok {
+		if f.AcceptUnknown {
+			return fakeSettledConfirmations, nil
+		}
+		return 0, ErrPaymentNotFound
+	}
+
+	if p.Err != nil {
+		return p.Confirmations, p.Err
+	}
+	if p.Wallet != "" && !strings.EqualFold(p.Wallet, claim.Wallet) {
+		return 0, fmt.Errorf("%w: not sent from your wallet_address", ErrPaymentRejected)
+	}
+	if p.Amount != nil && p.Amount.Cmp(claim.Amount) < 0 {
+		return 0, fmt.Errorf("%w: paid %s, below the price of %s", ErrPaymentRejected, p.Amount, claim.Amount)
+	}
+	return p.Confirmations, nil
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	testAdminWallet = "0x00000000000000000000000000000000000000aa"
+	testPayerWallet = "0x00000000000000000000000000000000000000bb"
+	testTokenAddr   = "0x00000000000000000000000000000000000000cc"
+	testOtherWallet = "0x00000000000000000000000000000000000000dd"
+	testTxHash      = "0x1111111111111111111111111111111111111111111111111111111111111111"
+)
+
+// rpcStub serves JSON-RPC results by method name. Methods without a result return null.
+type rpcStub struct {
+	results map[string]interface{}
+	errors  map[string]string
+}
+
+func newRPCStub(t *testing.T, stub rpcStub) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": stub.results[req.Method]}
+		if msg, ok := stub.errors[req.Method]; ok {
+			resp = map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "error": map[string]interface{}{"code": -32000, "message": msg}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func strPtr(s string) *string { return &s }
+
+// hexWord left-pads a hex quantity or address to one 32-byte ABI word (64 hex characters).
+func hexWord(s string) string {
+	s = strings.TrimPrefix(s, "0x")
+	return strings.Repeat("0", 64-len(s)) + s
+}
+
+func testClaim(amount int64) PaymentClaim {
+	return PaymentClaim{Reference: testTxHash, UserID: "user", Wallet: testPayerWallet, Amount: big.NewInt(amount)}
+}
+
+func TestEthPaymentVerifier(t *testing.T) {
+	mined := ethTransaction{From: testPayerWallet, To: strPtr(testAdminWallet), Value: "0x3e8", BlockNumber: strPtr("0x10")}
+	pending := mined
+	pending.BlockNumber = nil
+
+	withTx := func(tx ethTransaction, change func(*ethTransaction)) ethTransaction {
+		change(&tx)
+		return tx
+	}
+
+	tests := []struct {
+		name          string
+		stub          rpcStub
+		claim         PaymentClaim
+		confirmations int
+		want          error
+	}{
+		{
+			name: "mined",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash":  mined,
+				"eth_getTransactionReceipt": ethReceipt{Status: "0x1", BlockNumber: "0x10"},
+				"eth_blockNumber":           "0x14",
+			}},
+			claim:         testClaim(1000),
+			confirmations: 5,
+		},
+		{
+			name: "overpaid",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash":  mined,
+				"eth_getTransactionReceipt": ethReceipt{Status: "0x1", BlockNumber: "0x10"},
+				"eth_blockNumber":           "0x10",
+			}},
+			claim:         testClaim(999),
+			confirmations: 1,
+		},
+		{
+			name:  "pending",
+			stub:  rpcStub{results: map[string]interface{}{"eth_getTransactionByHash": pending}},
+			claim: testClaim(1000),
+			want:  ErrPaymentPending,
+		},
+		{
+			name: "pending from another wallet",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash": withTx(pending, func(tx *ethTransaction) { tx.From = testOtherWallet }),
+			}},
+			claim: testClaim(1000),
+			want:  ErrPaymentRejected,
+		},
+		{
+			name: "pending to another wallet",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash": withTx(pending, func(tx *ethTransaction) { tx.To = strPtr(testOtherWallet) }),
+			}},
+			claim: testClaim(1000),
+			want:  ErrPaymentRejected,
+		},
+		{
+			name: "pending contract creation",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash": withTx(pending, func(tx *ethTransaction) { tx.To = nil }),
+			}},
+			claim: testClaim(1000),
+			want:  ErrPaymentRejected,
+		},
+		{
+			name:  "pending below the price",
+			stub:  rpcStub{results: map[string]interface{}{"eth_getTransactionByHash": pending}},
+			claim: testClaim(1001),
+			want:  ErrPaymentRejected,
+		},
+		{
+			name:  "not found",
+			stub:  rpcStub{},
+			claim: testClaim(1000),
+			want:  ErrPaymentNotFound,
+		},
+		{
+			name:  "mined without receipt yet",
+			stub:  rpcStub{results: map[string]interface{}{"eth_getTransactionByHash": mined}},
+			claim: testClaim(1000),
+			want:  ErrPaymentPending,
+		},
+		{
+			name: "reverted",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash":  mined,
+				"eth_getTransactionReceipt": ethReceipt{Status: "0x0", BlockNumber: "0x10"},
+				"eth_blockNumber":           "0x14",
+			}},
+			claim: testClaim(1000),
+			want:  ErrPaymentRejected,
+		},
+		{
+			name:  "no verified wallet",
+			stub:  rpcStub{results: map[string]interface{}{"eth_getTransactionByHash": mined}},
+			claim: PaymentClaim{Reference: testTxHash, UserID: "user", Amount: big.NewInt(1000)},
+			want:  ErrWalletRequired,
+		},
+		{
+			name:  "node error",
+			stub:  rpcStub{errors: map[string]string{"eth_getTransactionByHash": "rate limited"}},
+			claim: testClaim(1000),
+			want:  ErrPaymentUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewEthPaymentVerifier(newRPCStub(t, tt.stub), testAdminWallet)
+			confirmations, err := v.Verify(context.Background(), tt.claim)
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if confirmations != tt.confirmations {
+				t.Errorf("confirmations = %d, want %d", confirmations, tt.confirmations)
+			}
+		})
+	}
+}
+
+func TestEthPaymentVerifierUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	v := NewEthPaymentVerifier(srv.URL, testAdminWallet)
+	if _, err := v.Verify(context.Background(), testClaim(1000)); !errors.Is(err, ErrPaymentUnavailable) {
+		t.Errorf("err = %v, want ErrPaymentUnavailable", err)
+	}
+}
+
+func TestERC20PaymentVerifier(t *testing.T) {
+	transferLog := func(token, from, to string, amount int64) ethLog {
+		return ethLog{
+			Address: token,
+			Topics:  []string{erc20TransferTopic, "0x" + hexWord(from), "0x" + hexWord(to)},
+			Data:    "0x" + hexWord(fmt.Sprintf("%x", amount)),
+		}
+	}
+	receipt := func(status string, logs ...ethLog) ethReceipt {
+		return ethReceipt{Status: status, BlockNumber: "0x10", Logs: logs}
+	}
+	transferCall := func(from, token, to string, amount int64) ethTransaction {
+		return ethTransaction{
+			From:  from,
+			To:    strPtr(token),
+			Input: erc20TransferSelector + hexWord(to) + hexWord(fmt.Sprintf("%x", amount)),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		stub          rpcStub
+		amount        int64
+		confirmations int
+		want          error
+	}{
+		{
+			name: "mined",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionReceipt": receipt("0x1", transferLog(testTokenAddr, testPayerWallet, testAdminWallet, 500)),
+				"eth_blockNumber":           "0x12",
+			}},
+			amount:        500,
+			confirmations: 3,
+		},
+		{
+			name: "mined in several transfers",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionReceipt": receipt("0x1",
+					transferLog(testTokenAddr, testPayerWallet, testAdminWallet, 300),
+					transferLog(testTokenAddr, testPayerWallet, testAdminWallet, 200)),
+				"eth_blockNumber": "0x10",
+			}},
+			amount:        500,
+			confirmations: 1,
+		},
+		{
+			name: "mined below the price",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionReceipt": receipt("0x1", transferLog(testTokenAddr, testPayerWallet, testAdminWallet, 499)),
+				"eth_blockNumber":           "0x10",
+			}},
+			amount: 500,
+			want:   ErrPaymentRejected,
+		},
+		{
+			name: "mined transfers of other tokens, payers or recipients",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionReceipt": receipt("0x1",
+					transferLog(testOtherWallet, testPayerWallet, testAdminWallet, 500),
+					transferLog(testTokenAddr, testOtherWallet, testAdminWallet, 500),
+					transferLog(testTokenAddr, testPayerWallet, testOtherWallet, 500)),
+				"eth_blockNumber": "0x10",
+			}},
+			amount: 500,
+			want:   ErrPaymentRejected,
+		},
+		{
+			name: "reverted",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionReceipt": receipt("0x0", transferLog(testTokenAddr, testPayerWallet, testAdminWallet, 500)),
+			}},
+			amount: 500,
+			want:   ErrPaymentRejected,
+		},
+		{
+			name: "pending transfer",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash": transferCall(testPayerWallet, testTokenAddr, testAdminWallet, 500),
+			}},
+			amount: 500,
+			want:   ErrPaymentPending,
+		},
+		{
+			name: "pending transfer from another wallet",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash": transferCall(testOtherWallet, testTokenAddr, testAdminWallet, 500),
+			}},
+			amount: 500,
+			want:   ErrPaymentRejected,
+		},
+		{
+			name: "pending transfer to another wallet",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash": transferCall(testPayerWallet, testTokenAddr, testOtherWallet, 500),
+			}},
+			amount: 500,
+			want:   ErrPaymentRejected,
+		},
+		{
+			name: "pending transfer of another token",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash": transferCall(testPayerWallet, testOtherWallet, testAdminWallet, 500),
+			}},
+			amount: 500,
+			want:   ErrPaymentRejected,
+		},
+		{
+			name: "pending transfer below the price",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash": transferCall(testPayerWallet, testTokenAddr, testAdminWallet, 499),
+			}},
+			amount: 500,
+			want:   ErrPaymentRejected,
+		},
+		{
+			name: "pending call that isn't a transfer",
+			stub: rpcStub{results: map[string]interface{}{
+				"eth_getTransactionByHash": ethTransaction{From: testPayerWallet, To: strPtr(testTokenAddr), Input: "0x095ea7b3" + hexWord(testAdminWallet) + hexWord("1f4")},
+			}},
+			amount: 500,
+			want:   ErrPaymentRejected,
+		},
+		{
+			name:   "not found",
+			stub:   rpcStub{},
+			amount: 500,
+			want:   ErrPaymentNotFound,
+		},
+		{
+			name:   "node error",
+			stub:   rpcStub{errors: map[string]string{"eth_getTransactionReceipt": "rate limited"}},
+			amount: 500,
+			want:   ErrPaymentUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewERC20PaymentVerifier(newRPCStub(t, tt.stub), testAdminWallet, testTokenAddr)
+			confirmations, err := v.Verify(context.Background(), testClaim(tt.amount))
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if confirmations != tt.confirmations {
+				t.Errorf("confirmations = %d, want %d", confirmations, tt.confirmations)
+			}
+		})
+	}
+}
+
+func TestERC20PaymentVerifierWalletRequired(t *testing.T) {
+	v := NewERC20PaymentVerifier(newRPCStub(t, rpcStub{}), testAdminWallet, testTokenAddr)
+	claim := testClaim(500)
+	claim.Wallet = ""
+	if _, err := v.Verify(context.Background(), claim); err != ErrWalletRequired {
+		t.Errorf("err = %v, want ErrWalletRequired", err)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/Akshatt02/job-portal-backend/internal/db"
 	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
 	"github.com/google/uuid"
 )
 
@@ -43,68 +45,73 @@ func CreatePost(userID, content string) (string, error) {
 	return postID, nil
 }
 
-// GetPosts retrieves all posts from the social feed, ordered by newest first.
+// GetPosts retrieves a page of posts from the social feed, ordered by newest first.
 //
 // Parameters:
 // - limit: Maximum number of posts to return
+// - cursor: Opaque next_cursor from the previous page ("" for the first page)
 //
 // Returns:
 // - posts: Slice of Post objects with user details
-// - error: if database query fails
+// - next cursor ("" when there are no more posts)
+// - error: if the cursor is invalid or database query fails
 //
 // Includes user name for each post (via JOIN with users table)
-func GetPosts(limit int) ([]models.Post, error) {
-	query := `
-		SELECT p.id, p.user_id, u.name, p.content, p.created_at
-		FROM posts p
-		JOIN users u ON p.user_id = u.id
-		ORDER BY p.created_at DESC
-		LIMIT $1
-	`
-
-	rows, err := db.Pool.Query(context.Background(), query, limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var posts []models.Post
-	for rows.Next() {
-		var p models.Post
-		if err := rows.Scan(&p.ID, &p.UserID, &p.UserName, &p.Content, &p.CreatedAt); err != nil {
-			log.Println(err)
-			return nil, err
-		}
-		posts = append(posts, p)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return posts, nil
+func GetPosts(limit int, cursor string) ([]models.Post, string, error) {
+	return listPosts("", limit, cursor)
 }
 
-// GetUserPosts retrieves all posts created by a specific user.
+// GetUserPosts retrieves a page of posts created by a specific user, newest first.
 //
 // Parameters:
 // - userID: UUID of the user whose posts to fetch
+// - limit: Maximum number of posts to return
+// - cursor: Opaque next_cursor from the previous page ("" for the first page)
 //
 // Returns:
 // - posts: Slice of Post objects
-// - error: if database query fails
-func GetUserPosts(userID string) ([]models.Post, error) {
+// - next cursor ("" when there are no more posts)
+// - error: if the cursor is invalid or database query fails
+func GetUserPosts(userID string, limit int, cursor string) ([]models.Post, string, error) {
+	return listPosts(userID, limit, cursor)
+}
+
+// listPosts runs the keyset-paginated feed query on (created_at, id),
+// optionally restricted to a single author.
+func listPosts(userID string, limit int, cursor string) ([]models.Post, string, error) {
 	query := `
 		SELECT p.id, p.user_id, u.name, p.content, p.created_at
 		FROM posts p
 		JOIN users u ON p.user_id = u.id
-		WHERE p.user_id = $1
-		ORDER BY p.created_at DESC
+		WHERE 1=1
 	`
 
-	rows, err := db.Pool.Query(context.Background(), query, userID)
+	var args []interface{}
+	argCount := 1
+
+	if userID != "" {
+		query += ` AND p.user_id = $` + strconv.Itoa(argCount)
+		args = append(args, userID)
+		argCount++
+	}
+
+	if cursor != "" {
+		after, err := utils.DecodeCursor(cursor)
+		if err != nil || after.Rank != nil {
+			return nil, "", utils.ErrInvalidCursor
+		}
+		query += ` AND (p.created_at, p.id) < ($` + strconv.Itoa(argCount) + `, $` + strconv.Itoa(argCount+1) + `)`
+		args = append(args, after.CreatedAt, after.ID)
+		argCount += 2
+	}
+
+	// Fetch one extra row to learn whether another page exists
+	query += ` ORDER BY p.created_at DESC, p.id DESC LIMIT $` + strconv.Itoa(argCount)
+	args = append(args, limit+1)
+
+	rows, err := db.Pool.Query(context.Background(), query, args...)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer rows.Close()
 
@@ -112,14 +119,22 @@ func GetUserPosts(userID string) ([]models.Post, error) {
 	for rows.Next() {
 		var p models.Post
 		if err := rows.Scan(&p.ID, &p.UserID, &p.UserName, &p.Content, &p.CreatedAt); err != nil {
-			return nil, err
+			log.Println(err)
+			return nil, "", err
 		}
 		posts = append(posts, p)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	next := ""
+	if len(posts) > limit {
+		posts = posts[:limit]
+		last := posts[len(posts)-1]
+		next = utils.EncodeCursor(utils.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
 	}
 
-	return posts, nil
+	return posts, next, nil
 }
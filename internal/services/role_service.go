@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRole  = errors.New("role must be candidate, recruiter or admin")
+	ErrRoleNotHeld  = errors.New("user does not have this role")
+	ErrLastAdmin    = errors.New("cannot revoke the last admin")
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// Platform roles (user_roles.role). Every account is a candidate; recruiters post jobs;
+// admins manage plans and roles.
+const (
+	RoleCandidate = "candidate"
+	RoleRecruiter = "recruiter"
+	RoleAdmin     = "admin"
+)
+
+// Roles lists all platform roles, least privileged first.
+var Roles = []string{RoleCandidate, RoleRecruiter, RoleAdmin}
+
+// Permissions checked by middleware.RequirePermission.
+const (
+	PermJobsApply       = "jobs:apply"
+	PermJobsCreate      = "jobs:create"
+	PermCreditsPurchase = "credits:purchase"
+	PermCompaniesCreate = "companies:create"
+	PermPostsCreate     = "posts:create"
+	PermPlansManage     = "plans:manage"
+	PermRolesManage     = "roles:manage"
+)
+
+// rolePermissions maps each role to what it may do. A user's permissions are the
+// union over their roles.
+var rolePermissions = map[string][]string{
+	RoleCandidate: {PermJobsApply, PermPostsCreate},
+	RoleRecruiter: {PermJobsCreate, PermCreditsPurchase, PermCompaniesCreate, PermPostsCreate},
+	RoleAdmin: {PermJobsApply, PermJobsCreate, PermCreditsPurchase, PermCompaniesCreate, PermPostsCreate,
+		PermPlansManage, PermRolesManage},
+}
+
+// Role audit actions (role_audit_log.action).
+const (
+	RoleActionGrant  = "grant"
+	RoleActionRevoke = "revoke"
+)
+
+// roleHistoryLimit caps the audit entries returned by GetUserRoles.
+const roleHistoryLimit = 100
+
+// IsValidRole reports whether role is one of Roles.
+func IsValidRole(role string) bool {
+	return contains(Roles, role)
+}
+
+// HasPermission reports whether any of roles grants perm.
+func HasPermission(roles []string, perm string) bool {
+	for _, r := range roles {
+		if contains(rolePermissions[r], perm) {
+			return true
+		}
+	}
+	return false
+}
+
+// grantRole gives userID the role and records it in the audit log. Granting a role the
+// user already has is a no-op and isn't logged. actorID is nil for grants made by the
+// system on the user's own behalf.
+func grantRole(ctx context.Context, q execer, userID uuid.UUID, role string, actorID *uuid.UUID) error {
+	tag, err := q.Exec(ctx,
+		`INSERT INTO user_roles (user_id, role, granted_by, granted_at) VALUES ($1, $2, $3, NOW())
+		 ON CONFLICT (user_id, role) DO NOTHING`,
+		userID, role, actorID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+	_, err = q.Exec(ctx,
+		`INSERT INTO role_audit_log (id, user_id, role, action, actor_id, created_at) VALUES ($1, $2, $3, $4, $5, NOW())`,
+		uuid.New(), userID, role, RoleActionGrant, actorID)
+	return err
+}
+
+// GetRoles returns the user's roles, least privileged first.
+//
+// Usage: Called when issuing access tokens (roles claim) and by GET /me
+func GetRoles(userID string) ([]string, error) {
+	rows, err := db.Pool.Query(context.Background(),
+		`SELECT role FROM user_roles WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	held := map[string]bool{}
+	for rows.Next() {
+		var r string
+		if err := rows.Scan(&r); err != nil {
+			return nil, err
+		}
+		held[r] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	roles := []string{}
+	for _, r := range Roles {
+		if held[r] {
+			roles = append(roles, r)
+		}
+	}
+	return roles, nil
+}
+
+// GrantRole gives a user a platform role and records who granted it
+//
+// Parameters:
+// - userIDStr: UUID string of the user receiving the role
+// - role: candidate, recruiter or admin
+// - actorIDStr: UUID string of the admin granting it
+//
+// The role is in the user's access tokens from their next POST /auth/refresh.
+//
+// Returns:
+// - ErrInvalidRole, ErrUserNotFound or database error
+//
+// Usage: Called by POST /admin/users/:id/roles
+func GrantRole(userIDStr, role, actorIDStr string) error {
+	if !IsValidRole(role) {
+		return ErrInvalidRole
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		return err
+	}
+
+	var exists bool
+	if err := db.Pool.QueryRow(context.Background(),
+		`SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, userID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrUserNotFound
+	}
+
+	return grantRole(context.Background(), db.Pool, userID, role, &actorID)
+}
+
+// RevokeRole removes a platform role from a user and records who revoked it
+//
+// Process:
+// 1. Lock the admin roles when revoking admin, and refuse to remove the last one
+// 2. Delete the role and append a revoke entry to the audit log
+// 3. Revoke the user's sessions (reason "role_change"), since their access tokens still carry the role
+//
+// Returns:
+// - ErrInvalidRole, ErrRoleNotHeld, ErrLastAdmin or database error
+//
+// Usage: Called by DELETE /admin/users/:id/roles/:role
+func RevokeRole(userIDStr, role, actorIDStr string) error {
+	if !IsValidRole(role) {
+		return ErrInvalidRole
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return ErrRoleNotHeld
+	}
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	if role == RoleAdmin {
+		var admins int
+		// Lock every admin row so two admins can't demote each other at the same time
+		rows, err := tx.Query(context.Background(),
+			`SELECT user_id FROM user_roles WHERE role = $1 FOR UPDATE`, RoleAdmin)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			admins++
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if admins <= 1 {
+			var held bool
+			if err := tx.QueryRow(context.Background(),
+				`SELECT EXISTS(SELECT 1 FROM user_roles WHERE user_id = $1 AND role = $2)`,
+				userID, RoleAdmin).Scan(&held); err != nil {
+				return err
+			}
+			if held {
+				return ErrLastAdmin
+			}
+		}
+	}
+
+	tag, err := tx.Exec(context.Background(),
+		`DELETE FROM user_roles WHERE user_id = $1 AND role = $2`, userID, role)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRoleNotHeld
+	}
+	if _, err := tx.Exec(context.Background(),
+		`INSERT INTO role_audit_log (id, user_id, role, action, actor_id, created_at) VALUES ($1, $2, $3, $4, $5, NOW())`,
+		uuid.New(), userID, role, RoleActionRevoke, actorID); err != nil {
+		return err
+	}
+	if _, err := revokeUserSessions(context.Background(), tx, userID.String(), SessionRevokedRoleChange); err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+// GetUserRoles retrieves a user's roles with their grant and revoke history
+//
+// Returns:
+// - *models.UserRoles with roles and up to 100 audit entries, newest first
+// - ErrUserNotFound or database error
+//
+// Usage: Called by GET /admin/users/:id/roles
+func GetUserRoles(userIDStr string) (*models.UserRoles, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	var exists bool
+	if err := db.Pool.QueryRow(context.Background(),
+		`SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, userID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+
+	roles, err := GetRoles(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+	ur := &models.UserRoles{UserID: userID, Roles: roles, History: []models.RoleAuditEntry{}}
+
+	rows, err := db.Pool.Query(context.Background(),
+		`SELECT id, role, action, actor_id, created_at FROM role_audit_log
+		 WHERE user_id = $1
+		 ORDER BY created_at DESC, id DESC
+		 LIMIT $2`,
+		userID, roleHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e models.RoleAuditEntry
+		if err := rows.Scan(&e.ID, &e.Role, &e.Action, &e.ActorID, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		ur.History = append(ur.History, e)
+	}
+	return ur, rows.Err()
+}
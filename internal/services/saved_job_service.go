@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var ErrJobNotSaved = errors.New("job is not in your saved jobs")
+
+// SaveJob bookmarks a job for a user, or updates the note if it is already saved
+//
+// Process:
+// 1. Parse user ID and check the job exists (ErrJobNotFound if missing or not visible to the user, see GetVisibleJob)
+// 2. Upsert the saved_jobs row, replacing the note on conflict
+//
+// Parameters:
+// - jobIDStr: UUID string of the job
+// - userIDStr: UUID string of the user
+// - note: Optional private note (empty clears it)
+//
+// Returns:
+// - *models.SavedJob with the original save time
+// - created: true if the job was newly saved, false if only the note changed
+// - ErrJobNotFound if the job doesn't exist or the user may not see it
+// - Other error if database operation fails
+//
+// Usage: Called by POST /jobs/:id/save endpoint
+func SaveJob(jobIDStr, userIDStr, note string) (*models.SavedJob, bool, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, false, err
+	}
+
+	job, err := GetVisibleJob(jobIDStr, userIDStr)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// xmax is 0 only for freshly inserted rows, not for rows updated by ON CONFLICT
+	var created bool
+	saved := &models.SavedJob{JobID: job.ID, UserID: userID, Note: note}
+	err = db.Pool.QueryRow(context.Background(),
+		`INSERT INTO saved_jobs (user_id, job_id, note, created_at)
+		 VALUES ($1,$2,$3,NOW())
+		 ON CONFLICT (user_id, job_id) DO UPDATE SET note = EXCLUDED.note
+		 RETURNING created_at, (xmax = 0)`,
+		userID, job.ID, nullStr(note),
+	).Scan(&saved.CreatedAt, &created)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return saved, created, nil
+}
+
+// UnsaveJob removes a job from a user's saved jobs
+//
+// Returns:
+// - ErrJobNotSaved if the job wasn't saved by this user
+// - Other error if database operation fails
+//
+// Usage: Called by DELETE /jobs/:id/save endpoint
+func UnsaveJob(jobID, userID string) error {
+	tag, err := db.Pool.Exec(context.Background(),
+		"DELETE FROM saved_jobs WHERE user_id=$1 AND job_id=$2",
+		userID, jobID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrJobNotSaved
+	}
+	return nil
+}
+
+// IsJobSaved reports whether a user has saved a job.
+//
+// Usage: Called by GET /jobs/:id to fill the saved flag
+func IsJobSaved(jobID, userID string) (bool, error) {
+	var saved bool
+	err := db.Pool.QueryRow(context.Background(),
+		"SELECT EXISTS(SELECT 1 FROM saved_jobs WHERE user_id=$1 AND job_id=$2)",
+		userID, jobID,
+	).Scan(&saved)
+	return saved, err
+}
+
+// ListSavedJobs retrieves a user's saved jobs with the job postings, most recently saved first.
+// Saved jobs are returned whatever their status, so candidates can see when a posting closes.
+//
+// Parameters:
+// - userID: UUID string of the user
+//
+// Returns:
+// - saved: Slice of SavedJob objects with Job populated
+// - error: if database query fails
+//
+// Usage: Called by GET /me/saved-jobs endpoint
+func ListSavedJobs(userID string) ([]models.SavedJob, error) {
+	query := `
+		SELECT ` + jobColumns + `, s.saved_note, s.saved_at
+		FROM jobs
+		JOIN (SELECT job_id, note AS saved_note, created_at AS saved_at FROM saved_jobs WHERE user_id = $1) s
+			ON s.job_id = jobs.id
+		ORDER BY s.saved_at DESC
+	`
+
+	rows, err := db.Pool.Query(context.Background(), query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var saved []models.SavedJob
+	for rows.Next() {
+		var (
+			s    models.SavedJob
+			note *string
+		)
+		job, err := scanJob(rows, &note, &s.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		s.JobID = job.ID
+		s.UserID, _ = uuid.Parse(userID)
+		s.Note = safeStr(note)
+		s.Job = job
+		saved = append(saved, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSavedSearchNotFound = errors.New("saved search not found")
+	ErrEmptySavedSearch    = errors.New("saved search needs a name and at least one of skill, location or q")
+	ErrAlertNotFound       = errors.New("alert not found")
+)
+
+// alertMatchTimeout bounds one run of the saved-search matcher, including notifier delivery.
+const alertMatchTimeout = 2 * time.Minute
+
+// CreateSavedSearch stores a named search for new-job alerts
+//
+// Parameters:
+// - userIDStr: UUID string of the owner
+// - name: Display name
+// - skill, location, query: Filters with GET /jobs semantics (skill=, location=, q=)
+//
+// Returns:
+// - *models.SavedSearch on success
+// - ErrEmptySavedSearch if the name or all filters are empty
+// - Other error if database operation fails
+//
+// Usage: Called by POST /me/saved-searches endpoint
+func CreateSavedSearch(userIDStr, name, skill, location, query string) (*models.SavedSearch, error) {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &models.SavedSearch{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		Skill:     strings.TrimSpace(skill),
+		Location:  strings.TrimSpace(location),
+		Query:     strings.TrimSpace(query),
+		CreatedAt: time.Now(),
+	}
+	if s.Name == "" || (s.Skill == "" && s.Location == "" && s.Query == "") {
+		return nil, ErrEmptySavedSearch
+	}
+
+	_, err = db.Pool.Exec(context.Background(),
+		`INSERT INTO saved_searches (id, user_id, name, skill, location, query, created_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7)`,
+		s.ID, s.UserID, s.Name, nullStr(s.Skill), nullStr(s.Location), nullStr(s.Query), s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ListSavedSearches retrieves a user's saved searches, newest first.
+//
+// Usage: Called by GET /me/saved-searches endpoint
+func ListSavedSearches(userID string) ([]models.SavedSearch, error) {
+	rows, err := db.Pool.Query(context.Background(),
+		`SELECT id, user_id, name, skill, location, query, created_at
+		 FROM saved_searches
+		 WHERE user_id = $1
+		 ORDER BY created_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var searches []models.SavedSearch
+	for rows.Next() {
+		var (
+			s                      models.SavedSearch
+			skill, location, query *string
+		)
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Name, &skill, &location, &query, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		s.Skill = safeStr(skill)
+		s.Location = safeStr(location)
+		s.Query = safeStr(query)
+		searches = append(searches, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return searches, nil
+}
+
+// DeleteSavedSearch removes one of a user's saved searches along with its alert hits.
+//
+// Returns:
+// - ErrSavedSearchNotFound if it doesn't exist or belongs to another user
+//
+// Usage: Called by DELETE /me/saved-searches/:id endpoint
+func DeleteSavedSearch(searchID, userID string) error {
+	tag, err := db.Pool.Exec(context.Background(),
+		"DELETE FROM saved_searches WHERE id=$1 AND user_id=$2",
+		searchID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+// MatchSavedSearches records alert hits for every saved search matching a job
+//
+// Process:
+// 1. Match all saved searches against the job in one statement (skipped unless the job is listed)
+// 2. Insert a hit per matching search; hits already recorded are skipped
+// 3. Deliver each new hit through the configured AlertNotifier
+//
+// The filter conditions mirror ListJobsWithFilters (skill, location, q) so an alert
+// fires exactly when the job would appear in the saved search's GET /jobs results.
+// The poster's own saved searches are not matched.
+//
+// Parameters:
+// - ctx: Context bounding the match and deliveries
+// - jobID: UUID string of the newly listed job
+//
+// Returns:
+// - error if the match query fails; delivery failures are logged and skipped
+//
+// Usage: Run in the background after a job is published (see notifyJobAlerts)
+func MatchSavedSearches(ctx context.Context, jobID string) error {
+	// keep in sync with the skill/location conditions in ListJobsWithFilters; q shares anyTermQuery
+	query := `
+		WITH hits AS (
+			INSERT INTO alert_hits (id, saved_search_id, user_id, job_id, created_at)
+			SELECT uuid_generate_v4(), s.id, s.user_id, j.id, NOW()
+			FROM saved_searches s
+			JOIN jobs j ON j.id = $1
+			WHERE s.user_id <> j.user_id
+			  AND j.status = $2 AND (j.expires_at IS NULL OR j.expires_at > NOW())
+			  AND (s.skill IS NULL OR j.skills::text ILIKE '%' || s.skill || '%')
+			  AND (s.location IS NULL OR j.location ILIKE '%' || s.location || '%')
+			  AND (s.query IS NULL OR j.search_vector @@ ` + anyTermQuery("s.query") + `)
+			ON CONFLICT (saved_search_id, job_id) DO NOTHING
+			RETURNING id, saved_search_id, user_id, job_id, created_at
+		)
+		SELECT h.id, h.saved_search_id, h.user_id, h.job_id, h.created_at, s.name, j.title, u.email
+		FROM hits h
+		JOIN saved_searches s ON s.id = h.saved_search_id
+		JOIN jobs j ON j.id = h.job_id
+		JOIN users u ON u.id = h.user_id
+	`
+
+	rows, err := db.Pool.Query(ctx, query, jobID, JobStatusPublished)
+	if err != nil {
+		return err
+	}
+
+	type delivery struct {
+		email string
+		hit   models.AlertHit
+	}
+	var deliveries []delivery
+	for rows.Next() {
+		var d delivery
+		if err := rows.Scan(&d.hit.ID, &d.hit.SavedSearchID, &d.hit.UserID, &d.hit.JobID, &d.hit.CreatedAt,
+			&d.hit.SearchName, &d.hit.JobTitle, &d.email); err != nil {
+			rows.Close()
+			return err
+		}
+		deliveries = append(deliveries, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	notifier := currentAlertNotifier()
+	if notifier == nil {
+		return nil
+	}
+	for _, d := range deliveries {
+		if err := notifier.Notify(ctx, d.email, d.hit); err != nil {
+			log.Println("alert delivery failed:", err)
+		}
+	}
+	return nil
+}
+
+// notifyJobAlerts runs the saved-search matcher for a job in the background.
+func notifyJobAlerts(jobID string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), alertMatchTimeout)
+		defer cancel()
+		if err := MatchSavedSearches(ctx, jobID); err != nil {
+			log.Println("saved search matching failed:", err)
+		}
+	}()
+}
+
+// ListAlertHits retrieves the in-app alert inbox for a user, newest first.
+//
+// Parameters:
+// - userID: UUID string of the user
+// - unreadOnly: Only return alerts not yet marked as read
+//
+// Returns:
+// - alerts: Slice of AlertHit objects with search name and job title (at most 100)
+// - error: if database query fails
+//
+// Usage: Called by GET /me/alerts endpoint
+func ListAlertHits(userID string, unreadOnly bool) ([]models.AlertHit, error) {
+	query := `
+		SELECT h.id, h.saved_search_id, h.user_id, h.job_id, h.created_at, h.read_at, s.name, j.title
+		FROM alert_hits h
+		JOIN saved_searches s ON s.id = h.saved_search_id
+		JOIN jobs j ON j.id = h.job_id
+		WHERE h.user_id = $1`
+	if unreadOnly {
+		query += ` AND h.read_at IS NULL`
+	}
+	query += ` ORDER BY h.created_at DESC LIMIT 100`
+
+	rows, err := db.Pool.Query(context.Background(), query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []models.AlertHit
+	for rows.Next() {
+		var h models.AlertHit
+		if err := rows.Scan(&h.ID, &h.SavedSearchID, &h.UserID, &h.JobID, &h.CreatedAt, &h.ReadAt, &h.SearchName, &h.JobTitle); err != nil {
+			return nil, err
+		}
+		hits = append(hits, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return hits, nil
+}
+
+// MarkAlertRead marks one of a user's alerts as read. Marking twice is a no-op.
+//
+// Returns:
+// - ErrAlertNotFound if it doesn't exist or belongs to another user
+//
+// Usage: Called by POST /me/alerts/:id/read endpoint
+func MarkAlertRead(alertID, userID string) error {
+	tag, err := db.Pool.Exec(context.Background(),
+		"UPDATE alert_hits SET read_at = COALESCE(read_at, NOW()) WHERE id=$1 AND user_id=$2",
+		alertID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAlertNotFound
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used; the session has been revoked, sign in again")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
+// Session revoke reasons (sessions.revoke_reason).
+const (
+	SessionRevokedLogout         = "logout"
+	SessionRevokedLogoutAll      = "logout_all"
+	SessionRevokedReuse          = "refresh_token_reuse"
+	SessionRevokedPasswordChange = "password_change"
+	SessionRevokedPasswordReset  = "password_reset"
+	SessionRevokedRoleChange     = "role_change"
+)
+
+// newRefreshToken returns a random opaque refresh token and the hash stored for it.
+// Only the hash is persisted, so a database leak doesn't expose usable tokens.
+func newRefreshToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashRefreshToken(token), nil
+}
+
+// hashRefreshToken returns the hex SHA-256 of a refresh token (refresh_tokens.token_hash).
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateSession starts a login session and issues its first refresh token
+//
+// Parameters:
+// - userID: UUID string of the user signing in
+// - userAgent / ip: Client details, kept for the user's reference
+// - ttl: How long the session lives without being refreshed (REFRESH_TOKEN_TTL_DAYS)
+//
+// Returns:
+// - sessionID: Put in the access token's "sid" claim
+// - refreshToken: Opaque token for POST /auth/refresh (only its hash is stored)
+// - error if the database insert fails
+//
+// Usage: Called by POST /auth/register, /auth/login and /auth/siwe/verify
+func CreateSession(userID, userAgent, ip string, ttl time.Duration) (string, string, error) {
+	token, hash, err := newRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return "", "", err
+	}
+	defer tx.Rollback(context.Background())
+
+	sessionID := uuid.New()
+	_, err = tx.Exec(context.Background(),
+		`INSERT INTO sessions (id, user_id, user_agent, ip, created_at, last_used_at, expires_at)
+		 VALUES ($1, $2, $3, $4, NOW(), NOW(), NOW() + make_interval(secs => $5))`,
+		sessionID, userID, nullStr(userAgent), nullStr(ip), ttl.Seconds())
+	if err != nil {
+		return "", "", err
+	}
+	_, err = tx.Exec(context.Background(),
+		`INSERT INTO refresh_tokens (id, session_id, token_hash, created_at) VALUES ($1, $2, $3, NOW())`,
+		uuid.New(), sessionID, hash)
+	if err != nil {
+		return "", "", err
+	}
+	if err := tx.Commit(context.Background()); err != nil {
+		return "", "", err
+	}
+
+	// Opportunistically drop sessions that ended over a day ago so the tables stay small
+	_, _ = db.Pool.Exec(context.Background(),
+		`DELETE FROM sessions WHERE COALESCE(revoked_at, expires_at) < NOW() - INTERVAL '1 day'`)
+
+	return sessionID.String(), token, nil
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one
+//
+// Process:
+// 1. Look up the token by hash and lock its session
+// 2. If the token was already exchanged, someone is replaying it: revoke the whole session (reason "refresh_token_reuse")
+// 3. Require the session to be neither revoked nor expired
+// 4. Mark the token used, issue its successor and extend the session by ttl
+//
+// Revoking on reuse means neither a thief holding a copied token nor the real client can continue.
+//
+// Parameters:
+// - token: Refresh token from the client
+// - ttl: New session lifetime from now (REFRESH_TOKEN_TTL_DAYS)
+//
+// Returns:
+// - userID / sessionID: For the new access token
+// - newToken: The replacement refresh token; the old one stops working
+// - ErrInvalidRefreshToken, ErrRefreshTokenReused or database error
+//
+// Usage: Called by POST /auth/refresh endpoint
+func RotateRefreshToken(token string, ttl time.Duration) (string, string, string, error) {
+	if token == "" {
+		return "", "", "", ErrInvalidRefreshToken
+	}
+
+	tx, err := db.Pool.Begin(context.Background())
+	if err != nil {
+		return "", "", "", err
+	}
+	defer tx.Rollback(context.Background())
+
+	var (
+		tokenID, sessionID, userID uuid.UUID
+		usedAt, revokedAt          *time.Time
+		expired                    bool
+	)
+	err = tx.QueryRow(context.Background(),
+		`SELECT t.id, t.used_at, s.id, s.user_id, s.revoked_at, s.expires_at < NOW()
+		 FROM refresh_tokens t
+		 JOIN sessions s ON s.id = t.session_id
+		 WHERE t.token_hash = $1
+		 FOR UPDATE OF s`,
+		hashRefreshToken(token),
+	).Scan(&tokenID, &usedAt, &sessionID, &userID, &revokedAt, &expired)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", "", "", ErrInvalidRefreshToken
+		}
+		return "", "", "", err
+	}
+
+	if revokedAt != nil || expired {
+		return "", "", "", ErrInvalidRefreshToken
+	}
+	if usedAt != nil {
+		_, err = tx.Exec(context.Background(),
+			`UPDATE sessions SET revoked_at = NOW(), revoke_reason = $2 WHERE id = $1`,
+			sessionID, SessionRevokedReuse)
+		if err != nil {
+			return "", "", "", err
+		}
+		if err := tx.Commit(context.Background()); err != nil {
+			return "", "", "", err
+		}
+		return "", "", "", ErrRefreshTokenReused
+	}
+
+	newToken, hash, err := newRefreshToken()
+	if err != nil {
+		return "", "", "", err
+	}
+	if _, err := tx.Exec(context.Background(),
+		`UPDATE refresh_tokens SET used_at = NOW() WHERE id = $1`, tokenID); err != nil {
+		return "", "", "", err
+	}
+	if _, err := tx.Exec(context.Background(),
+		`INSERT INTO refresh_tokens (id, session_id, token_hash, created_at) VALUES ($1, $2, $3, NOW())`,
+		uuid.New(), sessionID, hash); err != nil {
+		return "", "", "", err
+	}
+	if _, err := tx.Exec(context.Background(),
+		`UPDATE sessions SET last_used_at = NOW(), expires_at = NOW() + make_interval(secs => $2) WHERE id = $1`,
+		sessionID, ttl.Seconds()); err != nil {
+		return "", "", "", err
+	}
+	if err := tx.Commit(context.Background()); err != nil {
+		return "", "", "", err
+	}
+
+	return userID.String(), sessionID.String(), newToken, nil
+}
+
+// RevokeSession ends one of the user's sessions (logout)
+//
+// Access tokens of the session are rejected from then on and its refresh token stops working.
+//
+// Returns:
+// - ErrSessionNotFound if the session doesn't exist, belongs to someone else or is already revoked
+// - Database error otherwise
+//
+// Usage: Called by POST /auth/logout endpoint
+func RevokeSession(userID, sessionID string) error {
+	tag, err := db.Pool.Exec(context.Background(),
+		`UPDATE sessions SET revoked_at = NOW(), revoke_reason = $3
+		 WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`,
+		sessionID, userID, SessionRevokedLogout)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeAllSessions ends every active session of the user ("log out everywhere")
+//
+// Returns:
+// - int: Number of sessions revoked
+// - error if the database update fails
+//
+// Usage: Called by POST /auth/logout-all endpoint
+func RevokeAllSessions(userID string) (int, error) {
+	return revokeUserSessions(context.Background(), db.Pool, userID, SessionRevokedLogoutAll)
+}
+
+// execer is satisfied by both the pool and a transaction.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// revokeUserSessions revokes every active session of the user with the given reason.
+func revokeUserSessions(ctx context.Context, q execer, userID, reason string) (int, error) {
+	tag, err := q.Exec(ctx,
+		`UPDATE sessions SET revoked_at = NOW(), revoke_reason = $2
+		 WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()`,
+		userID, reason)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// IsSessionActive reports whether an access token's session still accepts requests:
+// it belongs to userID, isn't revoked and hasn't expired.
+//
+// Usage: Called by middleware.AuthRequired and middleware.AuthOptional on every request
+func IsSessionActive(sessionID, userID string) (bool, error) {
+	sid, err := uuid.Parse(sessionID)
+	if err != nil {
+		return false, nil
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return false, nil
+	}
+	var active bool
+	err = db.Pool.QueryRow(context.Background(),
+		`SELECT EXISTS(SELECT 1 FROM sessions
+		 WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > NOW())`,
+		sid, uid,
+	).Scan(&active)
+	return active, err
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrSIWENonce       = errors.New("invalid or expired nonce")
+	ErrSIWEDomain      = errors.New("message domain does not match this site")
+	ErrSIWEChain       = errors.New("message chain ID does not match the payment chain")
+	ErrSIWEExpired     = errors.New("message is expired or not yet valid")
+	ErrSIWESigner      = errors.New("signature was not made by the message address")
+	ErrWalletNotLinked = errors.New("no account has verified this wallet; sign in and link it first")
+	ErrWalletTaken     = errors.New("wallet is already verified by another account")
+)
+
+// SIWENonceTTL is how long a nonce from GET /auth/siwe/nonce can be used.
+const SIWENonceTTL = 10 * time.Minute
+
+// siweClockSkew tolerates small clock differences for Issued At / Not Before.
+const siweClockSkew = 5 * time.Minute
+
+// CreateSIWENonce issues a single-use nonce for a Sign-In with Ethereum message
+//
+// Returns:
+// - nonce: 32 hex characters (EIP-4361 requires at least 8 alphanumerics)
+// - expiresAt: When the nonce stops being accepted (now + SIWENonceTTL)
+// - error if the database insert fails
+//
+// Usage: Called by GET /auth/siwe/nonce endpoint
+func CreateSIWENonce() (string, time.Time, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, err
+	}
+	nonce := hex.EncodeToString(buf)
+	expiresAt := time.Now().Add(SIWENonceTTL)
+
+	_, err := db.Pool.Exec(context.Background(),
+		`INSERT INTO siwe_nonces (nonce, expires_at, created_at) VALUES ($1, NOW() + make_interval(secs => $2), NOW())`,
+		nonce, SIWENonceTTL.Seconds())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	// Opportunistically drop expired nonces so the table stays small
+	_, _ = db.Pool.Exec(context.Background(), `DELETE FROM siwe_nonces WHERE expires_at < NOW()`)
+
+	return nonce, expiresAt, nil
+}
+
+// VerifySIWE checks a signed Sign-In with Ethereum message and returns the proven wallet address
+//
+// Process:
+// 1. Parse the EIP-4361 message
+// 2. Require the configured domain and payment chain ID
+// 3. Check Issued At / Expiration Time / Not Before against the current time
+// 4. Recover the personal_sign (EIP-191) signer and require it to equal the message address
+// 5. Consume the nonce (single use, must not be expired)
+//
+// Parameters:
+// - message: The exact message the wallet signed
+// - signature: 0x-prefixed 65-byte signature
+// - domain: Expected message domain (SIWE_DOMAIN)
+// - chainID: Expected chain ID (ETH_CHAIN_ID)
+//
+// Returns:
+// - EIP-55 checksummed wallet address
+// - utils.ErrInvalidSIWEMessage (wrapped), utils.ErrInvalidSignature, ErrSIWEDomain, ErrSIWEChain, ErrSIWEExpired, ErrSIWESigner or ErrSIWENonce
+// - Other error if the database operation fails
+//
+// Usage: Called by POST /auth/siwe/verify endpoint
+func VerifySIWE(message, signature, domain string, chainID int64) (string, error) {
+	msg, err := utils.ParseSIWEMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	// The domain may carry a scheme (https://example.com); compare the authority only
+	msgDomain := msg.Domain
+	if _, rest, ok := strings.Cut(msgDomain, "://"); ok {
+		msgDomain = rest
+	}
+	if !strings.EqualFold(msgDomain, domain) {
+		return "", ErrSIWEDomain
+	}
+	if msg.ChainID != chainID {
+		return "", ErrSIWEChain
+	}
+
+	now := time.Now()
+	if msg.IssuedAt.After(now.Add(siweClockSkew)) ||
+		(msg.ExpirationTime != nil && !msg.ExpirationTime.After(now)) ||
+		(msg.NotBefore != nil && msg.NotBefore.After(now.Add(siweClockSkew))) {
+		return "", ErrSIWEExpired
+	}
+
+	signer, err := utils.RecoverPersonalSignAddress(message, signature)
+	if err != nil {
+		return "", err
+	}
+	if signer != msg.Address {
+		return "", ErrSIWESigner
+	}
+
+	tag, err := db.Pool.Exec(context.Background(),
+		`DELETE FROM siwe_nonces WHERE nonce = $1 AND expires_at > NOW()`, msg.Nonce)
+	if err != nil {
+		return "", err
+	}
+	if tag.RowsAffected() == 0 {
+		return "", ErrSIWENonce
+	}
+
+	return signer, nil
+}
+
+// LoginWithWallet finds the account that verified a wallet
+//
+// Returns:
+// - User ID (UUID string) on success
+// - ErrWalletNotLinked if no account has verified this wallet
+// - Other error if database query fails
+//
+// Usage: Called by POST /auth/siwe/verify without a token
+func LoginWithWallet(address string) (string, error) {
+	var id string
+	err := db.Pool.QueryRow(context.Background(),
+		`SELECT id::text FROM users WHERE lower(wallet_address) = lower($1) AND wallet_verified`,
+		address,
+	).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return "", ErrWalletNotLinked
+	}
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// LinkVerifiedWallet sets a user's wallet_address to a proven address and marks it verified
+//
+// A wallet can be verified by only one account (unique index on lower(wallet_address)
+// where wallet_verified); other accounts may still list it unverified.
+//
+// Returns:
+// - ErrWalletTaken if another account has already verified the wallet
+// - Other error if database operation fails
+//
+// Usage: Called by POST /auth/siwe/verify with a token
+func LinkVerifiedWallet(userID, address string) error {
+	_, err := db.Pool.Exec(context.Background(),
+		`UPDATE users SET wallet_address = $1, wallet_verified = TRUE WHERE id = $2`,
+		address, userID)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrWalletTaken
+	}
+	return err
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akshatt02/job-portal-backend/pkg/utils"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// siweTestAddress is the well-known address of the secp256k1 private key 1.
+const siweTestAddress = "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"
+
+func siweTestKey(n byte) *secp256k1.PrivateKey {
+	var b [32]byte
+	b[31] = n
+	return secp256k1.PrivKeyFromBytes(b[:])
+}
+
+// siweSign signs msg like personal_sign and returns the 0x-prefixed r || s || v signature.
+func siweSign(key *secp256k1.PrivateKey, msg string) string {
+	h := sha3.NewLegacyKeccak256()
+	fmt.Fprintf(h, "\x19Ethereum Signed Message:\n%d%s", len(msg), msg)
+	compact := ecdsa.SignCompact(key, h.Sum(nil), false) // v || r || s
+	return "0x" + hex.EncodeToString(append(compact[1:], compact[0]))
+}
+
+// siweTestMessage builds an EIP-4361 message for siweTestAddress.
+func siweTestMessage(domain string, chainID int64, issuedAt, expiresAt time.Time) string {
+	return fmt.Sprintf(`%s wants you to sign in with your Ethereum account:
+%s
+
+Sign in to Job Portal
+
+URI: https://%s
+Version: 1
+Chain ID: %d
+Nonce: 32891756abc
+Issued At: %s
+Expiration Time: %s`, domain, siweTestAddress, domain, chainID,
+		issuedAt.UTC().Format(time.RFC3339), expiresAt.UTC().Format(time.RFC3339))
+}
+
+// TestVerifySIWERejects covers the checks VerifySIWE makes before consuming the nonce,
+// so no database is needed.
+func TestVerifySIWERejects(t *testing.T) {
+	const domain = "app.example.com"
+	const chainID = 11155111
+	now := time.Now()
+	valid := siweTestMessage(domain, chainID, now, now.Add(10*time.Minute))
+	validSig := siweSign(siweTestKey(1), valid)
+
+	// The valid pair recovers the message address, so only the nonce check is left
+	if signer, err := utils.RecoverPersonalSignAddress(valid, validSig); err != nil || signer != siweTestAddress {
+		t.Fatalf("known-good pair: signer = %s, err = %v", signer, err)
+	}
+
+	tests := []struct {
+		name      string
+		message   string
+		signature string
+		want      error
+	}{
+		{
+			name:    "wrong domain",
+			message: siweTestMessage("evil.example.com", chainID, now, now.Add(10*time.Minute)),
+			want:    ErrSIWEDomain,
+		},
+		{
+			name:    "wrong chain",
+			message: siweTestMessage(domain, 1, now, now.Add(10*time.Minute)),
+			want:    ErrSIWEChain,
+		},
+		{
+			name:    "expired",
+			message: siweTestMessage(domain, chainID, now.Add(-time.Hour), now.Add(-time.Minute)),
+			want:    ErrSIWEExpired,
+		},
+		{
+			name:    "issued in the future",
+			message: siweTestMessage(domain, chainID, now.Add(time.Hour), now.Add(2*time.Hour)),
+			want:    ErrSIWEExpired,
+		},
+		{
+			name:      "signed by another key",
+			message:   valid,
+			signature: siweSign(siweTestKey(2), valid),
+			want:      ErrSIWESigner,
+		},
+		{
+			name:      "signature over another message",
+			message:   valid,
+			signature: siweSign(siweTestKey(1), strings.Replace(valid, "32891756abc", "32891756abd", 1)),
+			want:      ErrSIWESigner,
+		},
+		{
+			name:      "malformed signature",
+			message:   valid,
+			signature: validSig[:len(validSig)-2],
+			want:      utils.ErrInvalidSignature,
+		},
+		{
+			name:    "malformed message",
+			message: strings.Replace(valid, "Version: 1", "Version: 2", 1),
+			want:    utils.ErrInvalidSIWEMessage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig := tt.signature
+			if sig == "" {
+				sig = siweSign(siweTestKey(1), tt.message)
+			}
+			if _, err := VerifySIWE(tt.message, sig, domain, chainID); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifySIWEDomainWithScheme(t *testing.T) {
+	// A scheme in the message domain is ignored; a mismatch after it still fails
+	now := time.Now()
+	msg := siweTestMessage("https://evil.example.com", 11155111, now, now.Add(10*time.Minute))
+	if _, err := VerifySIWE(msg, siweSign(siweTestKey(1), msg), "app.example.com", 11155111); !errors.Is(err, ErrSIWEDomain) {
+		t.Errorf("err = %v, want ErrSIWEDomain", err)
+	}
+}
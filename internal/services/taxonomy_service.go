@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Akshatt02/job-portal-backend/internal/db"
+	"github.com/Akshatt02/job-portal-backend/internal/models"
+)
+
+var ErrInvalidTaxonomy = errors.New("invalid job classification")
+
+// EmploymentTypes are the allowed values for jobs.employment_type.
+var EmploymentTypes = []string{"full_time", "part_time", "contract", "internship", "temporary", "freelance"}
+
+// SeniorityLevels are the allowed values for jobs.seniority, most junior first.
+var SeniorityLevels = []string{"intern", "junior", "mid", "senior", "lead", "principal", "executive"}
+
+// IsValidEmploymentType reports whether v is one of EmploymentTypes.
+func IsValidEmploymentType(v string) bool {
+	return contains(EmploymentTypes, v)
+}
+
+// IsValidSeniority reports whether v is one of SeniorityLevels.
+func IsValidSeniority(v string) bool {
+	return contains(SeniorityLevels, v)
+}
+
+// ListCategories retrieves all job categories ordered by name.
+//
+// Returns:
+// - categories: Slice of Category objects
+// - error: if database query fails
+func ListCategories() ([]models.Category, error) {
+	rows, err := db.Pool.Query(context.Background(),
+		`SELECT slug, name FROM job_categories ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []models.Category
+	for rows.Next() {
+		var c models.Category
+		if err := rows.Scan(&c.Slug, &c.Name); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// GetTaxonomy returns every allowed category, employment type and seniority level.
+//
+// Usage: Called by GET /jobs/taxonomy endpoint
+func GetTaxonomy() (*models.Taxonomy, error) {
+	categories, err := ListCategories()
+	if err != nil {
+		return nil, err
+	}
+	if categories == nil {
+		categories = []models.Category{}
+	}
+	return &models.Taxonomy{
+		Categories:      categories,
+		EmploymentTypes: EmploymentTypes,
+		SeniorityLevels: SeniorityLevels,
+	}, nil
+}
+
+// ValidateTaxonomy checks optional job classification values.
+// Empty values are allowed (not classified).
+//
+// Returns an error wrapping ErrInvalidTaxonomy describing the first invalid value.
+func ValidateTaxonomy(category, employmentType, seniority string) error {
+	if employmentType != "" && !IsValidEmploymentType(employmentType) {
+		return fmt.Errorf("%w: unknown employment_type %q", ErrInvalidTaxonomy, employmentType)
+	}
+	if seniority != "" && !IsValidSeniority(seniority) {
+		return fmt.Errorf("%w: unknown seniority %q", ErrInvalidTaxonomy, seniority)
+	}
+	if category != "" {
+		var exists bool
+		err := db.Pool.QueryRow(context.Background(),
+			"SELECT EXISTS(SELECT 1 FROM job_categories WHERE slug=$1)", category).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: unknown category %q", ErrInvalidTaxonomy, category)
+		}
+	}
+	return nil
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
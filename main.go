@@ -18,6 +18,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/gofiber/fiber/v2"
@@ -28,6 +29,7 @@ import (
 	"github.com/Akshatt02/job-portal-backend/internal/db"
 	"github.com/Akshatt02/job-portal-backend/internal/handlers"
 	"github.com/Akshatt02/job-portal-backend/internal/middleware"
+	"github.com/Akshatt02/job-portal-backend/internal/services"
 )
 
 // main initializes the server and configures routes
@@ -35,10 +37,11 @@ import (
 // Initialization sequence:
 // 1. Load configuration from environment variables
 // 2. Connect to PostgreSQL database
-// 3. Create Fiber app with middleware (logging, CORS)
-// 4. Define public routes (no authentication required)
-// 5. Define protected routes (JWT authentication required)
-// 6. Start HTTP server on configured port
+// 3. Start background workers (job expiry sweeper) and configure the alert notifier
+// 4. Create Fiber app with middleware (logging, CORS)
+// 5. Define public routes (no authentication required)
+// 6. Define protected routes (JWT authentication required)
+// 7. Start HTTP server on configured port
 func main() {
 	// Load environment configuration (DATABASE_URL, PORT, JWT_SECRET, etc.)
 	cfg := config.LoadConfig()
@@ -47,6 +50,78 @@ func main() {
 	db.Connect(cfg.DatabaseURL)
 	defer db.Close()
 
+	// Background worker: expire published jobs past their expires_at
+	sweepCtx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+	services.StartJobExpirySweeper(sweepCtx, cfg.JobExpirySweepInterval)
+
+	// Job payments: one verifier per payment_method
+	if cfg.EthRPCURL != "" {
+		services.RegisterPaymentVerifier(services.PaymentMethodEVMNative,
+			services.NewEthPaymentVerifier(cfg.EthRPCURL, cfg.AdminWallet))
+		if cfg.ERC20TokenAddress != "" {
+			services.RegisterPaymentVerifier(services.PaymentMethodERC20,
+				services.NewERC20PaymentVerifier(cfg.EthRPCURL, cfg.AdminWallet, cfg.ERC20TokenAddress))
+		}
+	} else {
+		log.Println("ETH_RPC_URL not set: evm_native job payments are only checked for hash format")
+	}
+	if cfg.PaymentFake {
+		log.Println("PAYMENT_FAKE enabled: payment_method \"fake\" accepts any reference; never enable in production")
+		services.RegisterPaymentVerifier(services.PaymentMethodFake, services.NewFakePaymentVerifier(true))
+	}
+	if len(services.PaymentMethods()) > 0 {
+		// Background worker: publish pending_payment jobs once their payment is confirmed
+		services.StartPaymentConfirmationWorker(sweepCtx, cfg.PaymentPollInterval,
+			cfg.PaymentConfirmations, cfg.PaymentConfirmationTimeout)
+	}
+
+	// Saved-search alerts: always stored in-app, optionally delivered by log or email
+	switch cfg.AlertNotifier {
+	case "smtp":
+		services.SetAlertNotifier(services.SMTPNotifier{
+			Addr:     cfg.SMTPAddr,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+			JobURL:   cfg.FrontendURL + "/jobs/",
+		})
+	case "none":
+		services.SetAlertNotifier(nil)
+	case "log":
+		services.SetAlertNotifier(services.LogNotifier{})
+	default:
+		log.Fatalf("unknown ALERT_NOTIFIER %q (want log, smtp or none)", cfg.AlertNotifier)
+	}
+
+	// Account emails (verification and reset links): sent over SMTP in production, logged in
+	// development; with none, sending fails and the links never leave the server
+	switch cfg.Mailer {
+	case "smtp":
+		services.SetMailer(services.SMTPMailer{
+			Addr:     cfg.SMTPAddr,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+	case "log":
+		log.Println("MAILER=log: account emails, including password reset links, are written to the server log; use only in development")
+		services.SetMailer(services.LogMailer{})
+	case "none":
+		services.SetMailer(nil)
+	default:
+		log.Fatalf("unknown MAILER %q (want smtp, log or none)", cfg.Mailer)
+	}
+
+	// Social login (OIDC authorization code flow with PKCE)
+	for _, pc := range cfg.OIDCProviders {
+		p, err := services.NewOIDCProvider(pc.Name, pc.ClientID, pc.ClientSecret, pc.AuthURL, pc.TokenURL, pc.UserInfoURL)
+		if err != nil {
+			log.Fatalf("OIDC_PROVIDERS: %v", err)
+		}
+		services.RegisterOIDCProvider(p)
+	}
+
 	// Initialize Fiber web application
 	app := fiber.New()
 
@@ -72,23 +147,73 @@ func main() {
 	app.Post("/auth/register", handlers.Register)
 
 	// User login endpoint
-	// POST /auth/login { email, password } -> returns JWT token
+	// POST /auth/login { email, password } -> returns { token, refresh_token, expires_in }
+	// or { mfa_required, mfa_token } when two-factor authentication is enabled
 	app.Post("/auth/login", handlers.Login)
 
+	// Second login step for accounts with two-factor authentication
+	// POST /auth/2fa/verify { mfa_token, code } -> returns { token, refresh_token, expires_in }
+	app.Post("/auth/2fa/verify", handlers.VerifyMFALogin)
+
+	// Social login (OIDC with PKCE): list providers, start a login, finish it with the returned code
+	// GET /auth/oidc -> returns { providers: ["google", "linkedin"] }
+	// GET /auth/oidc/:provider -> returns { authorization_url, state }
+	// POST /auth/oidc/callback { code, state } -> returns { token, refresh_token, expires_in } (or an mfa challenge)
+	app.Get("/auth/oidc", handlers.ListOIDCProviders)
+	app.Get("/auth/oidc/:provider", handlers.StartOIDCLogin)
+	app.Post("/auth/oidc/callback", handlers.OIDCCallback)
+
+	// Exchange a refresh token for a new access token (rotates the refresh token)
+	// POST /auth/refresh { refresh_token } -> returns { token, refresh_token, expires_in }
+	app.Post("/auth/refresh", handlers.Refresh)
+
+	// Confirm an email address with the token from the verification link
+	// POST /auth/verify-email { token } -> returns { message, email_verified }
+	app.Post("/auth/verify-email", handlers.VerifyEmail)
+
+	// Email a single-use password reset link (same response whether or not the account exists)
+	// POST /auth/forgot-password { email } -> returns { message }
+	app.Post("/auth/forgot-password", handlers.ForgotPassword)
+
+	// Set a new password with the token from the reset link; signs out every session
+	// POST /auth/reset-password { token, password } -> returns { message }
+	app.Post("/auth/reset-password", handlers.ResetPassword)
+
+	// Sign-In with Ethereum (EIP-4361): single-use nonce for the signed message
+	// GET /auth/siwe/nonce -> returns { nonce, expires_at, domain, chain_id }
+	app.Get("/auth/siwe/nonce", handlers.GetSIWENonce)
+
+	// Verify a signed SIWE message: logs in by wallet, or links a verified wallet when a token is sent
+	// POST /auth/siwe/verify { message, signature } -> returns { token, refresh_token, expires_in, wallet_address } or the updated profile
+	app.Post("/auth/siwe/verify", middleware.AuthOptional(), handlers.VerifySIWE)
+
 	// Get public user profile (view someone else's profile)
 	// GET /profile/:id -> returns user info without sensitive data
 	app.Get("/profile/:id", handlers.GetProfile)
 
 	// List all jobs (browseable by anyone)
-	// GET /jobs -> returns array of job listings
-	app.Get("/jobs", handlers.ListJobs)
+	// GET /jobs?cursor= -> returns { items, next_cursor } of published job listings
+	// GET /jobs?status=draft -> caller's own jobs in that status (token required)
+	app.Get("/jobs", middleware.AuthOptional(), handlers.ListJobs)
+
+	// Allowed job categories, employment types and seniority levels
+	// GET /jobs/taxonomy -> returns { categories, employment_types, seniority_levels }
+	app.Get("/jobs/taxonomy", handlers.GetJobTaxonomy)
+
+	// Pricing plans for job postings
+	// GET /plans -> returns [ { slug, name, price_wei, duration_days, perks, ... } ] (active only)
+	app.Get("/plans", handlers.ListJobPlans)
+
+	// Public company profile
+	// GET /companies/:id -> returns { id, name, logo_url, website, description }
+	app.Get("/companies/:id", handlers.GetCompany)
 
 	// List all posts from social feed (browseable by anyone)
-	// GET /posts -> returns array of user posts
+	// GET /posts?cursor= -> returns { items, next_cursor } of user posts
 	app.Get("/posts", handlers.GetPosts)
 
 	// Get user's posts (public user profile posts)
-	// GET /posts/:user_id -> returns posts by specific user
+	// GET /posts/:user_id?cursor= -> returns { items, next_cursor } of posts by specific user
 	app.Get("/posts/:user_id", handlers.GetUserPosts)
 
 	// PROTECTED ROUTES (JWT authentication required)
@@ -98,10 +223,32 @@ func main() {
 	//
 	protected := app.Group("", middleware.AuthRequired())
 
+	// Sign out the current session, or every session of the user
+	// POST /auth/logout -> revokes this session's tokens
+	// POST /auth/logout-all -> returns { message, sessions_revoked }
+	protected.Post("/auth/logout", handlers.Logout)
+	protected.Post("/auth/logout-all", handlers.LogoutAll)
+
+	// Send a new verification link to the authenticated user's email (at most once a minute)
+	// POST /auth/verify-email/resend -> returns { message }
+	protected.Post("/auth/verify-email/resend", handlers.ResendVerificationEmail)
+
 	// Get current authenticated user's profile
 	// GET /me -> returns logged-in user's full profile
 	protected.Get("/me", handlers.Me)
 
+	// Change the password (current password required); signs out every session and returns a new one
+	// PUT /me/password { current_password, new_password } -> returns { token, refresh_token, expires_in }
+	protected.Put("/me/password", handlers.ChangePassword)
+
+	// Two-factor authentication (TOTP): setup returns a secret, enable confirms a code
+	// POST /me/2fa/setup -> returns { secret, otpauth_uri }
+	// POST /me/2fa/enable { code } -> returns { enabled, recovery_codes }
+	// POST /me/2fa/disable { password, code } -> returns { enabled }
+	protected.Post("/me/2fa/setup", handlers.SetupTOTP)
+	protected.Post("/me/2fa/enable", handlers.EnableTOTP)
+	protected.Post("/me/2fa/disable", handlers.DisableTOTP)
+
 	// Update authenticated user's profile
 	// PUT /profile { name, bio, skills, linkedin_url, wallet_address }
 	protected.Put("/profile", handlers.UpdateProfile)
@@ -110,10 +257,127 @@ func main() {
 	// GET /jobs/:id -> returns job + match_score based on user's skills
 	protected.Get("/jobs/:id", handlers.GetJob)
 
+	// Daily views, saves and applications for a job (poster or company member)
+	// GET /jobs/:id/stats?days=30 -> returns { job_id, totals, days }
+	protected.Get("/jobs/:id/stats", handlers.GetJobStats)
+
 	// Create a new job posting (requires blockchain payment)
-	// POST /jobs { title, description, location, payment_tx_hash }
+	// POST /jobs { title, description, location, payment_tx_hash, company_id }
 	// payment_tx_hash: Sepolia ETH transaction hash as proof of payment
-	protected.Post("/jobs", handlers.CreateJob)
+	// Requires a verified email address, and the jobs:create permission (recruiter or admin)
+	// or, with company_id, the owner or recruiter company role (checked by the handler)
+	protected.Post("/jobs", middleware.EmailVerifiedRequired(), handlers.CreateJob)
+
+	// Update a job posting (poster or company owner/recruiter, partial updates)
+	// PUT /jobs/:id { title, description, skills, salary, location } -> returns updated job
+	protected.Put("/jobs/:id", handlers.UpdateJob)
+
+	// Close a job posting (poster or company owner/recruiter) - hidden from listings, still reachable by ID
+	// POST /jobs/:id/close -> returns { id, status }
+	protected.Post("/jobs/:id/close", handlers.CloseJob)
+
+	// Change a job's lifecycle status (poster or company owner/recruiter)
+	// PUT /jobs/:id/status { status } -> returns updated job
+	protected.Put("/jobs/:id/status", handlers.SetJobStatus)
+
+	// Renew a job listing, republishing it if expired (poster or company owner/recruiter)
+	// POST /jobs/:id/renew { payment_tx_hash } -> returns renewed job
+	protected.Post("/jobs/:id/renew", handlers.RenewJob)
+
+	// Delete a job posting (poster or company owner/recruiter)
+	// DELETE /jobs/:id -> 204 No Content
+	protected.Delete("/jobs/:id", handlers.DeleteJob)
+
+	// Apply to a job (match score snapshotted at apply time)
+	// POST /jobs/:id/apply { cover_note, resume_url } -> returns application
+	// Requires the jobs:apply permission (candidate or admin)
+	protected.Post("/jobs/:id/apply", middleware.RequirePermission(services.PermJobsApply), handlers.ApplyToJob)
+
+	// List applications for a job (poster or company member)
+	// GET /jobs/:id/applications -> returns applications with applicant details
+	protected.Get("/jobs/:id/applications", handlers.ListJobApplications)
+
+	// Recruiter dashboard: the authenticated user's jobs with counters
+	// GET /me/jobs?status= -> returns { items: [{ job, stats }], next_cursor }
+	protected.Get("/me/jobs", handlers.MyJobs)
+
+	// List the authenticated user's applications
+	// GET /me/applications -> returns applications with job titles
+	protected.Get("/me/applications", handlers.MyApplications)
+
+	// Save a job to the authenticated user's bookmarks (saving again replaces the note)
+	// POST /jobs/:id/save { note } -> returns saved job entry
+	protected.Post("/jobs/:id/save", handlers.SaveJob)
+
+	// Remove a job from the authenticated user's bookmarks
+	// DELETE /jobs/:id/save -> 204 No Content
+	protected.Delete("/jobs/:id/save", handlers.UnsaveJob)
+
+	// List the authenticated user's saved jobs
+	// GET /me/saved-jobs -> returns saved jobs with notes, most recently saved first
+	protected.Get("/me/saved-jobs", handlers.MySavedJobs)
+
+	// Save a job search as a new-job alert
+	// POST /me/saved-searches { name, skill, location, q } -> returns saved search
+	protected.Post("/me/saved-searches", handlers.CreateSavedSearch)
+
+	// List the authenticated user's saved searches
+	// GET /me/saved-searches -> returns saved searches, newest first
+	protected.Get("/me/saved-searches", handlers.MySavedSearches)
+
+	// Delete a saved search and its alerts
+	// DELETE /me/saved-searches/:id -> 204 No Content
+	protected.Delete("/me/saved-searches/:id", handlers.DeleteSavedSearch)
+
+	// In-app inbox of new jobs matching the user's saved searches
+	// GET /me/alerts?unread=true -> returns alert hits, newest first
+	protected.Get("/me/alerts", handlers.MyAlerts)
+
+	// Posting credit balance and ledger history (yours, or a company's with ?company_id=)
+	// GET /me/credits -> returns { balances: [ { plan, credits } ], history: [...] }
+	protected.Get("/me/credits", handlers.MyCredits)
+
+	// Buy posting credits in bulk with one payment; POST /jobs without payment_tx_hash spends one
+	// POST /me/credits { plan, quantity, payment_tx_hash, payment_method, company_id } -> returns the account
+	// Requires the credits:purchase permission (recruiter or admin)
+	protected.Post("/me/credits", middleware.RequirePermission(services.PermCreditsPurchase), handlers.PurchaseCredits)
+
+	// Mark an alert as read
+	// POST /me/alerts/:id/read -> 204 No Content
+	protected.Post("/me/alerts/:id/read", handlers.MarkAlertRead)
+
+	// Create a company; the caller becomes its owner
+	// POST /companies { name, logo_url, website, description } -> returns company
+	// Requires the companies:create permission (recruiter or admin)
+	protected.Post("/companies", middleware.RequirePermission(services.PermCompaniesCreate), handlers.CreateCompany)
+
+	// Update a company profile (company owner only)
+	// PUT /companies/:id { name, logo_url, website, description } -> returns company
+	protected.Put("/companies/:id", handlers.UpdateCompany)
+
+	// List the companies the authenticated user belongs to
+	// GET /me/companies -> returns companies with the caller's role
+	protected.Get("/me/companies", handlers.MyCompanies)
+
+	// List a company's members (any member)
+	// GET /companies/:id/members -> returns members with roles
+	protected.Get("/companies/:id/members", handlers.ListCompanyMembers)
+
+	// Add a registered user to a company (company owner only)
+	// POST /companies/:id/members { email, role } -> returns membership
+	protected.Post("/companies/:id/members", handlers.AddCompanyMember)
+
+	// Change a member's role (company owner only)
+	// PUT /companies/:id/members/:user_id { role } -> 204 No Content
+	protected.Put("/companies/:id/members/:user_id", handlers.UpdateCompanyMember)
+
+	// Remove a member, or leave the company when removing yourself
+	// DELETE /companies/:id/members/:user_id -> 204 No Content
+	protected.Delete("/companies/:id/members/:user_id", handlers.RemoveCompanyMember)
+
+	// List a company's jobs in any status (any member)
+	// GET /companies/:id/jobs?status= -> returns { items, next_cursor }
+	protected.Get("/companies/:id/jobs", handlers.ListCompanyJobs)
 
 	// Extract skills from resume/bio text using AI
 	// POST /ai/extract-skills { bio } -> returns { skills: [...] }
@@ -121,7 +385,35 @@ func main() {
 
 	// Create a new social feed post (career advice, updates)
 	// POST /posts { content } -> returns { id, message }
-	protected.Post("/posts", handlers.CreatePost)
+	// Requires the posts:create permission and a verified email address
+	protected.Post("/posts", middleware.RequirePermission(services.PermPostsCreate), middleware.EmailVerifiedRequired(), handlers.CreatePost)
+
+	// ADMIN ROUTES (admin role required)
+	admin := protected.Group("/admin", middleware.RequireRole(services.RoleAdmin))
+
+	// List all pricing plans, including inactive ones
+	// GET /admin/plans -> returns [ { slug, name, price_wei, duration_days, perks, active, sort_order } ]
+	admin.Get("/plans", handlers.AdminListJobPlans)
+
+	// Add a pricing plan
+	// POST /admin/plans { slug, name, price_wei, duration_days, perks, active, sort_order } -> returns the plan
+	admin.Post("/plans", middleware.RequirePermission(services.PermPlansManage), handlers.CreateJobPlan)
+
+	// Edit a pricing plan (set active=false to retire it)
+	// PUT /admin/plans/:slug { name?, price_wei?, duration_days?, perks?, active?, sort_order? } -> returns the plan
+	admin.Put("/plans/:slug", middleware.RequirePermission(services.PermPlansManage), handlers.UpdateJobPlan)
+
+	// A user's roles with their grant/revoke audit trail
+	// GET /admin/users/:id/roles -> returns { user_id, roles, history }
+	admin.Get("/users/:id/roles", handlers.GetUserRoles)
+
+	// Grant a role (candidate, recruiter, admin); recorded with the admin as actor
+	// POST /admin/users/:id/roles { role } -> returns { user_id, roles, history }
+	admin.Post("/users/:id/roles", middleware.RequirePermission(services.PermRolesManage), handlers.GrantUserRole)
+
+	// Revoke a role and sign the user out everywhere; the last admin can't be removed
+	// DELETE /admin/users/:id/roles/:role -> 204 No Content
+	admin.Delete("/users/:id/roles/:role", middleware.RequirePermission(services.PermRolesManage), handlers.RevokeUserRole)
 
 	// Start HTTP server
 	log.Println("Starting server on port", cfg.Port)
@@ -36,4 +36,589 @@ CREATE TABLE IF NOT EXISTS posts (
 
 -- Create index on posts for efficient queries
 CREATE INDEX IF NOT EXISTS idx_posts_user_id ON posts(user_id);
-CREATE INDEX IF NOT EXISTS idx_posts_created_at ON posts(created_at DESC);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_posts_created_at ON posts(created_at DESC);
+-- applications table (candidates applying to jobs)
+CREATE TABLE IF NOT EXISTS applications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    job_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    cover_note TEXT,
+    resume_url TEXT,
+    match_score INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (job_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_applications_user_id ON applications(user_id);
+CREATE INDEX IF NOT EXISTS idx_applications_job_id ON applications(job_id);
+
+-- job status: 'published' jobs are listed, 'closed' jobs are only reachable by ID
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published';
+CREATE INDEX IF NOT EXISTS idx_jobs_status_created_at ON jobs(status, created_at DESC);
+
+-- job lifecycle: draft, published, paused, expired, filled, closed
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('draft', 'published', 'paused', 'expired', 'filled', 'closed'));
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+UPDATE jobs SET expires_at = created_at + INTERVAL '30 days'
+    WHERE expires_at IS NULL AND status = 'published';
+CREATE INDEX IF NOT EXISTS idx_jobs_expires_at ON jobs(expires_at) WHERE status IN ('published', 'paused');
+
+-- job renewals (audit trail of listing extensions and their payments)
+CREATE TABLE IF NOT EXISTS job_renewals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    job_id UUID NOT NULL,
+    payment_tx_hash TEXT,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_renewals_job_id ON job_renewals(job_id);
+
+-- full-text search for jobs: title outweighs skills, location and description
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(skills::text, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(location, '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'D')
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_jobs_search_vector ON jobs USING GIN (search_vector);
+
+-- keyset pagination on (created_at, id)
+CREATE INDEX IF NOT EXISTS idx_jobs_status_created_at_id ON jobs(status, created_at DESC, id DESC);
+DROP INDEX IF EXISTS idx_jobs_status_created_at;
+CREATE INDEX IF NOT EXISTS idx_posts_created_at_id ON posts(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_user_id_created_at_id ON posts(user_id, created_at DESC, id DESC);
+
+-- structured salary: min/max amounts, ISO 4217 currency, pay period
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS salary_min NUMERIC(14, 2);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS salary_max NUMERIC(14, 2);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS salary_currency CHAR(3);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS salary_period TEXT;
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_salary_period_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_salary_period_check
+    CHECK (salary_period IN ('hourly', 'monthly', 'yearly'));
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_salary_range_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_salary_range_check
+    CHECK (salary_min IS NULL OR salary_max IS NULL OR salary_min <= salary_max);
+
+-- yearly equivalents used by the salary_min/salary_max filters
+-- (multipliers must match salaryPeriodsPerYear in services/job_service.go)
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS salary_min_annual NUMERIC
+    GENERATED ALWAYS AS (salary_min * CASE salary_period WHEN 'hourly' THEN 2080 WHEN 'monthly' THEN 12 ELSE 1 END) STORED;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS salary_max_annual NUMERIC
+    GENERATED ALWAYS AS (salary_max * CASE salary_period WHEN 'hourly' THEN 2080 WHEN 'monthly' THEN 12 ELSE 1 END) STORED;
+CREATE INDEX IF NOT EXISTS idx_jobs_salary_annual ON jobs(salary_min_annual, salary_max_annual);
+
+-- best-effort parser for legacy free-text salaries, e.g.
+--   '$120k-150k' -> 120000, 150000, USD, yearly
+--   '€4,000 - 5,000 / month' -> 4000, 5000, EUR, monthly
+--   '12-18 LPA' -> 1200000, 1800000, INR, yearly
+-- Returns NULL amounts when no number is found ("Competitive", "DOE", ...).
+CREATE OR REPLACE FUNCTION parse_legacy_salary(
+    raw TEXT,
+    OUT min_amount NUMERIC,
+    OUT max_amount NUMERIC,
+    OUT currency TEXT,
+    OUT period TEXT
+) LANGUAGE plpgsql IMMUTABLE AS $$
+DECLARE
+    s TEXT := lower(coalesce(raw, ''));
+    m TEXT[];
+    amounts NUMERIC[] := '{}';
+    suffixes TEXT[] := '{}';
+    tmp NUMERIC;
+BEGIN
+    FOR m IN SELECT regexp_matches(s, '(\d[\d,]*(?:\.\d+)?)\s*(lpa|lakhs?|k|m|l)?\M', 'g') LOOP
+        amounts := amounts || replace(m[1], ',', '')::NUMERIC;
+        suffixes := suffixes || coalesce(m[2], '');
+    END LOOP;
+
+    IF coalesce(array_length(amounts, 1), 0) = 0 THEN
+        RETURN;
+    END IF;
+
+    -- "120-150k": a single trailing suffix applies to the whole range
+    IF array_length(amounts, 1) >= 2 AND suffixes[1] = '' AND suffixes[2] <> '' THEN
+        suffixes[1] := suffixes[2];
+    END IF;
+
+    FOR i IN 1..least(array_length(amounts, 1), 2) LOOP
+        amounts[i] := amounts[i] * CASE
+            WHEN suffixes[i] = 'k' THEN 1000
+            WHEN suffixes[i] = 'm' THEN 1000000
+            WHEN suffixes[i] IN ('l', 'lpa', 'lakh', 'lakhs') THEN 100000
+            ELSE 1
+        END;
+    END LOOP;
+
+    min_amount := amounts[1];
+    max_amount := CASE WHEN array_length(amounts, 1) >= 2 THEN amounts[2] ELSE amounts[1] END;
+    IF max_amount < min_amount THEN
+        tmp := min_amount;
+        min_amount := max_amount;
+        max_amount := tmp;
+    END IF;
+
+    currency := CASE
+        WHEN s ~ '\$|usd' THEN 'USD'
+        WHEN s ~ '€|eur' THEN 'EUR'
+        WHEN s ~ '£|gbp' THEN 'GBP'
+        WHEN s ~ '₹|inr|lpa|lakh' THEN 'INR'
+        ELSE NULL
+    END;
+
+    period := CASE
+        WHEN s ~ 'hour|/\s*hr|/\s*h\M' THEN 'hourly'
+        WHEN s ~ 'month|/\s*mo\M|\mpm\M' THEN 'monthly'
+        ELSE 'yearly'
+    END;
+END;
+$$;
+
+UPDATE jobs
+SET salary_min = parsed.min_amount,
+    salary_max = parsed.max_amount,
+    salary_currency = parsed.currency,
+    salary_period = parsed.period
+FROM (
+    SELECT j.id, p.*
+    FROM jobs j, LATERAL parse_legacy_salary(j.salary) p
+    WHERE j.salary IS NOT NULL AND j.salary <> ''
+      AND j.salary_min IS NULL AND j.salary_max IS NULL
+) parsed
+WHERE jobs.id = parsed.id AND parsed.min_amount IS NOT NULL;
+
+-- legacy free-text column is kept for reference but no longer written
+COMMENT ON COLUMN jobs.salary IS 'DEPRECATED: legacy free-text salary, see salary_min/salary_max/salary_currency/salary_period';
+
+-- structured job locations: work arrangement, country, city, coordinates, timezone
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS work_arrangement TEXT;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS country TEXT;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS city TEXT;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS utc_offset NUMERIC(4, 2);
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_work_arrangement_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_work_arrangement_check
+    CHECK (work_arrangement IN ('remote', 'hybrid', 'onsite'));
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_coordinates_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_coordinates_check
+    CHECK ((latitude IS NULL) = (longitude IS NULL)
+           AND (latitude IS NULL OR latitude BETWEEN -90 AND 90)
+           AND (longitude IS NULL OR longitude BETWEEN -180 AND 180));
+
+CREATE INDEX IF NOT EXISTS idx_jobs_latitude ON jobs(latitude) WHERE latitude IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_jobs_country ON jobs(country);
+
+-- best-effort backfill of the arrangement from free-text locations
+UPDATE jobs SET work_arrangement = 'hybrid'
+    WHERE work_arrangement IS NULL AND location ILIKE '%hybrid%';
+UPDATE jobs SET work_arrangement = 'remote'
+    WHERE work_arrangement IS NULL AND location ILIKE '%remote%';
+
+-- job taxonomy: categories lookup table plus employment type and seniority enums
+CREATE TABLE IF NOT EXISTS job_categories (
+    slug TEXT PRIMARY KEY,
+    name TEXT NOT NULL
+);
+
+INSERT INTO job_categories (slug, name) VALUES
+    ('engineering', 'Engineering'),
+    ('data', 'Data & Analytics'),
+    ('devops', 'DevOps & Infrastructure'),
+    ('security', 'Security'),
+    ('design', 'Design'),
+    ('product', 'Product Management'),
+    ('marketing', 'Marketing'),
+    ('sales', 'Sales'),
+    ('customer_support', 'Customer Support'),
+    ('finance', 'Finance'),
+    ('hr', 'People & HR'),
+    ('operations', 'Operations'),
+    ('legal', 'Legal'),
+    ('other', 'Other')
+ON CONFLICT (slug) DO NOTHING;
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS category TEXT REFERENCES job_categories(slug);
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS employment_type TEXT;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS seniority TEXT;
+-- keep in sync with EmploymentTypes / SeniorityLevels in services/taxonomy_service.go
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_employment_type_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_employment_type_check
+    CHECK (employment_type IN ('full_time', 'part_time', 'contract', 'internship', 'temporary', 'freelance'));
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_seniority_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_seniority_check
+    CHECK (seniority IN ('intern', 'junior', 'mid', 'senior', 'lead', 'principal', 'executive'));
+
+CREATE INDEX IF NOT EXISTS idx_jobs_category ON jobs(category);
+
+-- saved jobs (candidate bookmarks with a private note)
+CREATE TABLE IF NOT EXISTS saved_jobs (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, job_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_jobs_user_created_at ON saved_jobs(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_saved_jobs_job_id ON saved_jobs(job_id);
+
+-- saved searches and their alert hits (in-app inbox)
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    skill TEXT,
+    location TEXT,
+    query TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (skill IS NOT NULL OR location IS NOT NULL OR query IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user_id ON saved_searches(user_id);
+
+CREATE TABLE IF NOT EXISTS alert_hits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    saved_search_id UUID NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMP,
+    UNIQUE (saved_search_id, job_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_hits_user_created_at ON alert_hits(user_id, created_at DESC);
+
+-- companies: hiring teams sharing job postings
+CREATE TABLE IF NOT EXISTS companies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL,
+    logo_url TEXT,
+    website TEXT,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS company_members (
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'recruiter', 'viewer')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (company_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_members_user_id ON company_members(user_id);
+
+-- deleting a company keeps its jobs as personal postings of their posters
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS company_id UUID REFERENCES companies(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_jobs_company_created_at ON jobs(company_id, created_at DESC);
+
+-- job activity events backing the recruiter dashboard (views, saves, applications)
+CREATE TABLE IF NOT EXISTS job_events (
+    id BIGSERIAL PRIMARY KEY,
+    job_id UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    type TEXT NOT NULL CHECK (type IN ('view', 'save', 'unsave', 'apply')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_events_job_type_created_at ON job_events(job_id, type, created_at);
+
+-- payments ledger: every consumed payment transaction, usable for exactly one job
+-- (rows outlive deleted jobs so a hash can't be recycled by deleting the job)
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    chain_id BIGINT NOT NULL,
+    tx_hash TEXT NOT NULL, -- lowercased
+    job_id UUID REFERENCES jobs(id) ON DELETE SET NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    purpose TEXT NOT NULL CHECK (purpose IN ('job_post', 'job_renewal')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (chain_id, tx_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_job_id ON payments(job_id);
+
+-- backfill: the earliest use of each historical (Sepolia) hash owns the ledger entry
+INSERT INTO payments (chain_id, tx_hash, job_id, user_id, purpose, created_at)
+SELECT DISTINCT ON (lower(h.tx_hash)) 11155111, lower(h.tx_hash), h.job_id, h.user_id, h.purpose, h.created_at
+FROM (
+    SELECT payment_tx_hash AS tx_hash, id AS job_id, user_id, 'job_post' AS purpose, created_at
+    FROM jobs
+    WHERE payment_tx_hash IS NOT NULL AND payment_tx_hash <> ''
+    UNION ALL
+    SELECT r.payment_tx_hash, r.job_id, j.user_id, 'job_renewal', r.created_at
+    FROM job_renewals r
+    JOIN jobs j ON j.id = r.job_id
+    WHERE r.payment_tx_hash IS NOT NULL AND r.payment_tx_hash <> ''
+) h
+WHERE NOT EXISTS (SELECT 1 FROM payments p WHERE p.chain_id = 11155111 AND p.tx_hash = lower(h.tx_hash))
+ORDER BY lower(h.tx_hash), h.created_at, h.job_id
+ON CONFLICT DO NOTHING;
+
+-- flag historical postings and renewals whose hash was first used elsewhere
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS payment_reused BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE jobs j SET payment_reused = TRUE
+WHERE j.payment_tx_hash IS NOT NULL AND j.payment_tx_hash <> ''
+  AND NOT EXISTS (
+      SELECT 1 FROM payments p
+      WHERE p.chain_id = 11155111 AND p.tx_hash = lower(j.payment_tx_hash)
+        AND p.job_id = j.id AND p.purpose = 'job_post'
+  );
+
+ALTER TABLE job_renewals ADD COLUMN IF NOT EXISTS payment_reused BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE job_renewals r SET payment_reused = TRUE
+WHERE r.payment_tx_hash IS NOT NULL AND r.payment_tx_hash <> ''
+  AND NOT EXISTS (
+      SELECT 1 FROM payments p
+      WHERE p.chain_id = 11155111 AND p.tx_hash = lower(r.payment_tx_hash)
+        AND p.job_id = r.job_id AND p.purpose = 'job_renewal' AND p.created_at = r.created_at
+  );
+
+-- pending payments: jobs wait in pending_payment until the confirmation worker
+-- confirms their payment (or fails it after a timeout)
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check
+    CHECK (status IN ('draft', 'published', 'paused', 'expired', 'filled', 'closed', 'pending_payment', 'payment_failed'));
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'confirmed';
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_status_check;
+ALTER TABLE payments ADD CONSTRAINT payments_status_check
+    CHECK (status IN ('pending', 'confirmed', 'failed'));
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS confirm_status TEXT; -- job status applied on confirmation
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS confirmations INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS failure_reason TEXT;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS payer_wallet TEXT; -- verified wallet the payment was checked against
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS checked_at TIMESTAMP;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS confirmed_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_payments_pending ON payments(created_at) WHERE status = 'pending';
+
+-- failed payments free their hash: only pending and confirmed rows consume it, so a
+-- transaction whose hash was squatted or timed out can still be used once it is mined
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_chain_id_tx_hash_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_chain_tx_hash_active ON payments(chain_id, tx_hash) WHERE status <> 'failed';
+
+-- Sign-In with Ethereum: wallet ownership proof
+ALTER TABLE users ADD COLUMN IF NOT EXISTS wallet_verified BOOLEAN NOT NULL DEFAULT FALSE;
+-- a wallet can be verified by one account only
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_verified_wallet ON users (lower(wallet_address)) WHERE wallet_verified;
+
+-- single-use nonces for SIWE messages (GET /auth/siwe/nonce)
+CREATE TABLE IF NOT EXISTS siwe_nonces (
+    nonce TEXT PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- pricing plans for job postings: each plan sets the payment amount and listing duration
+CREATE TABLE IF NOT EXISTS job_plans (
+    slug TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    price_wei NUMERIC(78, 0) NOT NULL CHECK (price_wei >= 0),
+    duration_days INTEGER NOT NULL CHECK (duration_days BETWEEN 1 AND 365),
+    perks JSONB NOT NULL DEFAULT '[]',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- basic keeps the previous flat price (0.001 ETH) and 30-day listing
+INSERT INTO job_plans (slug, name, price_wei, duration_days, perks, sort_order) VALUES
+    ('basic', 'Basic', 1000000000000000, 30, '["Listed for 30 days"]', 1),
+    ('featured', 'Featured', 5000000000000000, 45, '["Listed for 45 days", "Highlighted in listings", "Featured badge"]', 2),
+    ('urgent', 'Urgent', 3000000000000000, 30, '["Listed for 30 days", "Urgent badge"]', 3)
+ON CONFLICT (slug) DO NOTHING;
+
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS plan TEXT NOT NULL DEFAULT 'basic';
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_plan_fkey;
+ALTER TABLE jobs ADD CONSTRAINT jobs_plan_fkey FOREIGN KEY (plan) REFERENCES job_plans(slug);
+
+-- amount the payment had to cover (plan price at purchase time)
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS amount_wei NUMERIC(78, 0);
+
+-- platform admins manage pricing plans (granted directly in the database)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- pluggable payment providers: the ledger records which payment_method verified each payment
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS method TEXT NOT NULL DEFAULT 'evm_native';
+
+-- per-method plan prices in the method's smallest unit; only evm_native falls back to price_wei
+ALTER TABLE job_plans ADD COLUMN IF NOT EXISTS prices JSONB NOT NULL DEFAULT '{}';
+-- erc20 prices assume a 6-decimal stablecoin (USDC)
+UPDATE job_plans SET prices = '{"erc20": "2000000"}' WHERE slug = 'basic' AND prices = '{}';
+UPDATE job_plans SET prices = '{"erc20": "10000000"}' WHERE slug = 'featured' AND prices = '{}';
+UPDATE job_plans SET prices = '{"erc20": "6000000"}' WHERE slug = 'urgent' AND prices = '{}';
+
+-- prepaid posting credits: one payment buys N postings on a plan, POST /jobs without a hash spends one
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_purpose_check;
+ALTER TABLE payments ADD CONSTRAINT payments_purpose_check
+    CHECK (purpose IN ('job_post', 'job_renewal', 'credit_purchase'));
+
+-- append-only ledger: the balance of an account and plan is SUM(delta)
+CREATE TABLE IF NOT EXISTS posting_credits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,          -- personal credits
+    company_id UUID REFERENCES companies(id) ON DELETE CASCADE,   -- company credits
+    plan TEXT NOT NULL REFERENCES job_plans(slug),
+    delta INTEGER NOT NULL CHECK (delta <> 0),
+    reason TEXT NOT NULL CHECK (reason IN ('purchase', 'job_post')),
+    job_id UUID REFERENCES jobs(id) ON DELETE SET NULL,           -- job a credit was spent on
+    payment_id UUID REFERENCES payments(id),                      -- payment that bought the credits
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,        -- member who bought or spent them
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((user_id IS NULL) <> (company_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_posting_credits_user ON posting_credits(user_id, plan) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_posting_credits_company ON posting_credits(company_id, plan) WHERE company_id IS NOT NULL;
+
+-- login sessions: short-lived JWTs carry the session id (sid) so revoking a session logs it out
+CREATE TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_agent TEXT,
+    ip TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,   -- last POST /auth/refresh
+    expires_at TIMESTAMP NOT NULL,                      -- slides forward on every refresh
+    revoked_at TIMESTAMP,
+    revoke_reason TEXT CHECK (revoke_reason IN ('logout', 'logout_all', 'refresh_token_reuse'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions(user_id) WHERE revoked_at IS NULL;
+
+-- rotating refresh tokens, stored as SHA-256 hashes; a used token presented again revokes its session
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    used_at TIMESTAMP,                                  -- set when exchanged for its successor
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session ON refresh_tokens(session_id);
+
+-- email verification: posting (POST /jobs, POST /posts) needs a confirmed address.
+-- The column is added with DEFAULT TRUE so accounts that existed before are treated as verified,
+-- then the default flips so new registrations start unverified.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE users ALTER COLUMN email_verified SET DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verification_sent_at TIMESTAMP;  -- throttles resends
+
+-- password reset: single-use links, stored as SHA-256 hashes
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,                                  -- set on use, and on every open token once the password changes
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user ON password_reset_tokens(user_id);
+
+-- resetting or changing the password revokes the user's sessions
+ALTER TABLE sessions DROP CONSTRAINT IF EXISTS sessions_revoke_reason_check;
+ALTER TABLE sessions ADD CONSTRAINT sessions_revoke_reason_check
+    CHECK (revoke_reason IN ('logout', 'logout_all', 'refresh_token_reuse', 'password_change', 'password_reset'));
+
+-- two-factor authentication (RFC 6238 TOTP)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_secret TEXT;             -- active base32 secret
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_pending_secret TEXT;     -- from POST /me/2fa/setup until confirmed
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_last_step BIGINT;        -- time step of the last accepted code (no replay)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS mfa_failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS mfa_locked_until TIMESTAMP;   -- set after 5 wrong codes in a row
+
+-- one-time recovery codes, stored as SHA-256 hashes
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, code_hash)
+);
+
+-- social login (OIDC): pending logins and linked provider identities
+CREATE TABLE IF NOT EXISTS oidc_states (
+    state TEXT PRIMARY KEY,
+    provider TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,                        -- PKCE verifier, sent with the code exchange
+    redirect_uri TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,                             -- google, linkedin, ...
+    subject TEXT NOT NULL,                              -- the provider's stable user ID (sub)
+    email TEXT,                                         -- email the provider reported when linking
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user ON user_identities(user_id);
+
+-- role-based access control: platform roles and their audit trail
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('candidate', 'recruiter', 'admin')),
+    granted_by UUID REFERENCES users(id) ON DELETE SET NULL,  -- NULL for roles given by the system
+    granted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_role ON user_roles(role);
+
+CREATE TABLE IF NOT EXISTS role_audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('grant', 'revoke')),
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,    -- admin who made the change
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_role_audit_log_user ON role_audit_log(user_id, created_at DESC);
+
+-- backfill users from before roles existed (users with no role history yet, so revoked roles stay revoked):
+-- everyone is a candidate, is_admin users are admins, job posters are recruiters
+WITH backfill AS (
+    SELECT u.id, r.role
+    FROM users u
+    CROSS JOIN (VALUES ('candidate'), ('recruiter'), ('admin')) AS r(role)
+    WHERE NOT EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_id = u.id)
+      AND NOT EXISTS (SELECT 1 FROM role_audit_log a WHERE a.user_id = u.id)
+      AND (r.role = 'candidate'
+           OR (r.role = 'admin' AND u.is_admin)
+           OR (r.role = 'recruiter' AND EXISTS (SELECT 1 FROM jobs j WHERE j.user_id = u.id)))
+), granted AS (
+    INSERT INTO user_roles (user_id, role)
+    SELECT id, role FROM backfill
+    ON CONFLICT (user_id, role) DO NOTHING
+    RETURNING user_id, role
+)
+INSERT INTO role_audit_log (user_id, role, action)
+SELECT user_id, role, 'grant' FROM granted;
+
+-- is_admin is superseded by the admin role in user_roles and no longer read
+COMMENT ON COLUMN users.is_admin IS 'superseded by user_roles (role = admin); kept for the backfill only';
+
+-- revoking a role revokes the user's sessions
+ALTER TABLE sessions DROP CONSTRAINT IF EXISTS sessions_revoke_reason_check;
+ALTER TABLE sessions ADD CONSTRAINT sessions_revoke_reason_check
+    CHECK (revoke_reason IN ('logout', 'logout_all', 'refresh_token_reuse', 'password_change', 'password_reset', 'role_change'));
@@ -0,0 +1,52 @@
+// Package utils provides opaque cursor encoding for keyset pagination.
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the last row of a page in a (created_at, id) keyset ordering.
+//
+// Fields:
+// - CreatedAt: created_at of the last row returned
+// - ID: id of the last row returned (tie-breaker for equal timestamps)
+// - Rank: search rank of the last row, only set for relevance-ordered results
+//
+// Clients treat the encoded form as opaque and pass it back unchanged.
+type Cursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"i"`
+	Rank      *float64  `json:"r,omitempty"`
+}
+
+// EncodeCursor serializes a cursor into a URL-safe opaque string.
+//
+// Usage: next := EncodeCursor(Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+func EncodeCursor(c Cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a string produced by EncodeCursor.
+// Returns ErrInvalidCursor if the string is malformed.
+//
+// Usage: c, err := DecodeCursor(c.Query("cursor"))
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == uuid.Nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -9,11 +9,21 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// GenerateJWT creates a signed JWT token with a 72-hour expiration.
+// AccessClaims are the claims of an access token from GenerateJWT.
+type AccessClaims struct {
+	UserID    string
+	SessionID string
+	Roles     []string
+}
+
+// GenerateJWT creates a signed, short-lived access token for a session.
 //
 // Parameters:
 // - userID: User's UUID as string (stored in token claims)
+// - sessionID: UUID of the server-side session the token belongs to (see services.CreateSession)
+// - roles: The user's roles (candidate, recruiter, admin) at issue time
 // - secret: Secret key for HMAC-SHA256 signing
+// - ttl: Token lifetime (ACCESS_TOKEN_TTL_MINUTES); clients renew it with their refresh token
 //
 // Returns:
 // - token: Signed JWT string (can be sent to client)
@@ -21,21 +31,28 @@ import (
 //
 // Token Claims:
 // - user_id: The authenticated user's UUID
-// - exp: Token expiration time (current time + 72 hours)
+// - sid: The session ID; tokens of revoked sessions are rejected by middleware.AuthRequired
+// - roles: Checked by middleware.RequireRole / RequirePermission; new grants show up on the next refresh
+// - exp: Token expiration time (current time + ttl)
 // - iat: Token issued-at time
 //
-// Usage: token, err := GenerateJWT(userID, cfg.JWTSecret)
-func GenerateJWT(userID, secret string) (string, error) {
+// Usage: token, err := GenerateJWT(userID, sessionID, roles, cfg.JWTSecret, cfg.AccessTokenTTL)
+func GenerateJWT(userID, sessionID string, roles []string, secret string, ttl time.Duration) (string, error) {
+	if roles == nil {
+		roles = []string{}
+	}
 	claims := jwt.MapClaims{
 		"user_id": userID,
-		"exp":     time.Now().Add(time.Hour * 72).Unix(),
+		"sid":     sessionID,
+		"roles":   roles,
+		"exp":     time.Now().Add(ttl).Unix(),
 		"iat":     time.Now().Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secret))
 }
 
-// ParseToken validates and parses a JWT token, returning the user_id claim.
+// ParseToken validates and parses a JWT access token, returning its user_id, sid and roles claims.
 // Verifies the token signature and expiration.
 //
 // Parameters:
@@ -43,16 +60,16 @@ func GenerateJWT(userID, secret string) (string, error) {
 // - secret: Secret key used to sign the token (must match)
 //
 // Returns:
-// - userID: The user_id from token claims (as string UUID)
+// - *AccessClaims: user ID and session ID (as string UUIDs) and roles
 // - error: Returns nil only if token is valid and not expired
 //
 // Error conditions:
 // - "unexpected signing method": Token uses wrong algorithm
-// - "invalid token claims": Claims missing or invalid format
+// - "invalid token claims": Claims missing or invalid format (including tokens issued without a session)
 // - "token is invalid": Signature doesn't match or token expired
 //
-// Usage: userID, err := ParseToken(tokenStr, cfg.JWTSecret)
-func ParseToken(tokenStr, secret string) (string, error) {
+// Usage: claims, err := ParseToken(tokenStr, cfg.JWTSecret)
+func ParseToken(tokenStr, secret string) (*AccessClaims, error) {
 	parser := &jwt.Parser{}
 	token, err := parser.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
 		// Validate alg
@@ -62,14 +79,130 @@ func ParseToken(tokenStr, secret string) (string, error) {
 		return []byte(secret), nil
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		if uid, ok := claims["user_id"].(string); ok {
-			return uid, nil
+		uid, ok1 := claims["user_id"].(string)
+		sid, ok2 := claims["sid"].(string)
+		if ok1 && ok2 && sid != "" {
+			ac := &AccessClaims{UserID: uid, SessionID: sid, Roles: []string{}}
+			// Tokens issued before roles existed have no claim and carry no roles
+			if raw, ok := claims["roles"].([]interface{}); ok {
+				for _, r := range raw {
+					if role, ok := r.(string); ok {
+						ac.Roles = append(ac.Roles, role)
+					}
+				}
+			}
+			return ac, nil
+		}
+	}
+
+	return nil, errors.New("invalid token claims")
+}
+
+// Purposes of single-use tokens signed with the JWT secret. A purpose claim keeps
+// them from being used as access tokens (which need a sid) or for another flow.
+const (
+	emailVerificationPurpose = "verify_email"
+	mfaChallengePurpose      = "mfa_challenge"
+)
+
+var (
+	// ErrInvalidEmailToken is returned for malformed, forged or expired email verification tokens.
+	ErrInvalidEmailToken = errors.New("invalid or expired verification token")
+	// ErrInvalidMFAToken is returned for malformed, forged or expired two-factor login challenges.
+	ErrInvalidMFAToken = errors.New("invalid or expired mfa_token; sign in again")
+)
+
+// signPurposeToken signs claims plus purpose, exp and iat.
+func signPurposeToken(claims jwt.MapClaims, purpose, secret string, ttl time.Duration) (string, error) {
+	claims["purpose"] = purpose
+	claims["exp"] = time.Now().Add(ttl).Unix()
+	claims["iat"] = time.Now().Unix()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+// parsePurposeToken verifies a token from signPurposeToken and returns its claims,
+// or invalid if the signature, expiry or purpose is wrong.
+func parsePurposeToken(tokenStr, purpose, secret string, invalid error) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
 		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, invalid
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["purpose"] != purpose {
+		return nil, invalid
+	}
+	return claims, nil
+}
+
+// GenerateEmailVerificationToken creates a signed, expiring token for an email verification link.
+//
+// The token is bound to the address: if the user's email changes, older links stop working.
+//
+// Parameters:
+// - userID: User's UUID as string
+// - email: Address being verified
+// - secret: Secret key for HMAC-SHA256 signing (JWT_SECRET)
+// - ttl: Link lifetime (EMAIL_VERIFICATION_TTL_HOURS)
+//
+// Usage: token, err := GenerateEmailVerificationToken(userID, email, cfg.JWTSecret, cfg.EmailVerificationTTL)
+func GenerateEmailVerificationToken(userID, email, secret string, ttl time.Duration) (string, error) {
+	return signPurposeToken(jwt.MapClaims{"user_id": userID, "email": email}, emailVerificationPurpose, secret, ttl)
+}
+
+// ParseEmailVerificationToken validates a token from GenerateEmailVerificationToken.
+//
+// Returns:
+// - userID / email: The claims the token was issued for
+// - ErrInvalidEmailToken if the signature, expiry or purpose is wrong
+//
+// Usage: userID, email, err := ParseEmailVerificationToken(token, cfg.JWTSecret)
+func ParseEmailVerificationToken(tokenStr, secret string) (string, string, error) {
+	claims, err := parsePurposeToken(tokenStr, emailVerificationPurpose, secret, ErrInvalidEmailToken)
+	if err != nil {
+		return "", "", err
 	}
+	uid, ok1 := claims["user_id"].(string)
+	email, ok2 := claims["email"].(string)
+	if !ok1 || !ok2 {
+		return "", "", ErrInvalidEmailToken
+	}
+	return uid, email, nil
+}
+
+// GenerateMFAChallengeToken creates the short-lived token returned by the password step
+// of a login when the user has two-factor authentication enabled. It only proves the
+// first factor; POST /auth/2fa/verify exchanges it plus a code for a session.
+//
+// Usage: token, err := GenerateMFAChallengeToken(userID, cfg.JWTSecret, services.MFAChallengeTTL)
+func GenerateMFAChallengeToken(userID, secret string, ttl time.Duration) (string, error) {
+	return signPurposeToken(jwt.MapClaims{"user_id": userID}, mfaChallengePurpose, secret, ttl)
+}
 
-	return "", errors.New("invalid token claims")
+// ParseMFAChallengeToken validates a token from GenerateMFAChallengeToken.
+//
+// Returns:
+// - userID: The user who passed the password step
+// - ErrInvalidMFAToken if the signature, expiry or purpose is wrong
+//
+// Usage: userID, err := ParseMFAChallengeToken(req.MFAToken, cfg.JWTSecret)
+func ParseMFAChallengeToken(tokenStr, secret string) (string, error) {
+	claims, err := parsePurposeToken(tokenStr, mfaChallengePurpose, secret, ErrInvalidMFAToken)
+	if err != nil {
+		return "", err
+	}
+	uid, ok := claims["user_id"].(string)
+	if !ok {
+		return "", ErrInvalidMFAToken
+	}
+	return uid, nil
 }
@@ -0,0 +1,234 @@
+// Package utils provides Sign-In with Ethereum (EIP-4361) message parsing and
+// EIP-191 signature recovery.
+package utils
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	ErrInvalidSIWEMessage = errors.New("invalid sign-in with ethereum message")
+	ErrInvalidSignature   = errors.New("invalid signature")
+)
+
+const siweHeaderSuffix = " wants you to sign in with your Ethereum account:"
+
+// SIWEMessage holds the fields of an EIP-4361 message
+//
+// Fields:
+// - Domain: RFC 3986 authority requesting the signing (e.g. "app.example.com")
+// - Address: EIP-55 checksummed account address
+// - Statement: Optional human-readable assertion
+// - URI, Version, ChainID, Nonce, IssuedAt: Required fields (Version must be "1")
+// - ExpirationTime, NotBefore: Optional validity window (nil if absent)
+// - RequestID, Resources: Optional fields, parsed but not interpreted
+type SIWEMessage struct {
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// ParseSIWEMessage parses an EIP-4361 message
+//
+// Format:
+//
+//	example.com wants you to sign in with your Ethereum account:
+//	0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+//
+//	Sign in to Job Portal
+//
+//	URI: https://example.com
+//	Version: 1
+//	Chain ID: 11155111
+//	Nonce: 32891756
+//	Issued At: 2025-01-01T00:00:00Z
+//
+// Returns:
+// - *SIWEMessage with all fields present in the message
+// - error wrapping ErrInvalidSIWEMessage describing the first problem found
+//
+// Usage: Called by services.VerifySIWE before checking the signature
+func ParseSIWEMessage(msg string) (*SIWEMessage, error) {
+	lines := strings.Split(strings.ReplaceAll(msg, "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasSuffix(lines[0], siweHeaderSuffix) {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidSIWEMessage)
+	}
+
+	m := &SIWEMessage{Domain: strings.TrimSuffix(lines[0], siweHeaderSuffix)}
+	if m.Domain == "" {
+		return nil, fmt.Errorf("%w: missing domain", ErrInvalidSIWEMessage)
+	}
+	m.Address = lines[1]
+	if !IsChecksumAddress(m.Address) {
+		return nil, fmt.Errorf("%w: address must be EIP-55 checksummed", ErrInvalidSIWEMessage)
+	}
+	if lines[2] != "" {
+		return nil, fmt.Errorf("%w: expected blank line after address", ErrInvalidSIWEMessage)
+	}
+
+	i := 3
+	if !strings.HasPrefix(lines[i], "URI: ") {
+		m.Statement = lines[i]
+		if len(lines) < 6 || lines[i+1] != "" {
+			return nil, fmt.Errorf("%w: expected blank line after statement", ErrInvalidSIWEMessage)
+		}
+		i += 2
+	}
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" && i == len(lines)-1 {
+			break
+		}
+		if line == "Resources:" {
+			for i++; i < len(lines) && strings.HasPrefix(lines[i], "- "); i++ {
+				m.Resources = append(m.Resources, strings.TrimPrefix(lines[i], "- "))
+			}
+			i--
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected line %q", ErrInvalidSIWEMessage, line)
+		}
+		var err error
+		switch key {
+		case "URI":
+			m.URI = value
+		case "Version":
+			m.Version = value
+		case "Chain ID":
+			m.ChainID, err = strconv.ParseInt(value, 10, 64)
+		case "Nonce":
+			m.Nonce = value
+		case "Issued At":
+			m.IssuedAt, err = time.Parse(time.RFC3339, value)
+		case "Expiration Time":
+			var t time.Time
+			t, err = time.Parse(time.RFC3339, value)
+			m.ExpirationTime = &t
+		case "Not Before":
+			var t time.Time
+			t, err = time.Parse(time.RFC3339, value)
+			m.NotBefore = &t
+		case "Request ID":
+			m.RequestID = value
+		default:
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidSIWEMessage, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s", ErrInvalidSIWEMessage, key)
+		}
+	}
+
+	switch {
+	case m.URI == "":
+		return nil, fmt.Errorf("%w: missing URI", ErrInvalidSIWEMessage)
+	case m.Version != "1":
+		return nil, fmt.Errorf("%w: version must be 1", ErrInvalidSIWEMessage)
+	case m.ChainID == 0:
+		return nil, fmt.Errorf("%w: missing Chain ID", ErrInvalidSIWEMessage)
+	case len(m.Nonce) < 8:
+		return nil, fmt.Errorf("%w: nonce must be at least 8 characters", ErrInvalidSIWEMessage)
+	case m.IssuedAt.IsZero():
+		return nil, fmt.Errorf("%w: missing Issued At", ErrInvalidSIWEMessage)
+	}
+	return m, nil
+}
+
+// RecoverPersonalSignAddress returns the address that signed msg with personal_sign (EIP-191)
+//
+// The signed digest is keccak256("\x19Ethereum Signed Message:\n" + len(msg) + msg).
+//
+// Parameters:
+// - msg: The exact message that was signed
+// - sigHex: 0x-prefixed 65-byte signature r || s || v, with v in {0, 1, 27, 28}
+//
+// Returns:
+// - EIP-55 checksummed signer address
+// - ErrInvalidSignature if the signature is malformed or no key can be recovered
+func RecoverPersonalSignAddress(msg, sigHex string) (string, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(sigHex, "0x"))
+	if err != nil || len(sig) != 65 {
+		return "", ErrInvalidSignature
+	}
+
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return "", ErrInvalidSignature
+	}
+
+	// RecoverCompact expects v || r || s with v = 27 + recovery id (uncompressed key)
+	compact := make([]byte, 65)
+	compact[0] = 27 + v
+	copy(compact[1:], sig[:64])
+
+	digest := keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(msg), msg)))
+	pub, _, err := ecdsa.RecoverCompact(compact, digest)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+
+	// Address = last 20 bytes of keccak256 of the 64-byte uncompressed public key (without the 0x04 prefix)
+	addr := keccak256(pub.SerializeUncompressed()[1:])[12:]
+	return ToChecksumAddress("0x" + hex.EncodeToString(addr)), nil
+}
+
+// IsHexAddress reports whether s is a 0x-prefixed 20-byte hex address (any case).
+func IsHexAddress(s string) bool {
+	if len(s) != 42 || !strings.HasPrefix(s, "0x") {
+		return false
+	}
+	_, err := hex.DecodeString(s[2:])
+	return err == nil
+}
+
+// ToChecksumAddress returns the EIP-55 mixed-case form of a hex address.
+// Returns s unchanged if it isn't a valid address.
+func ToChecksumAddress(s string) string {
+	if !IsHexAddress(s) {
+		return s
+	}
+	lower := strings.ToLower(s[2:])
+	hash := hex.EncodeToString(keccak256([]byte(lower)))
+
+	out := []byte(lower)
+	for i, c := range out {
+		if c >= 'a' && c <= 'f' && hash[i] >= '8' {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// IsChecksumAddress reports whether s is a valid address in EIP-55 checksummed form.
+func IsChecksumAddress(s string) bool {
+	return IsHexAddress(s) && ToChecksumAddress(s) == s
+}
+
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
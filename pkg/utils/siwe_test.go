@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// testKeyAddress is the well-known address of the secp256k1 private key 1.
+const testKeyAddress = "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"
+
+func testKey(t *testing.T, n byte) *secp256k1.PrivateKey {
+	t.Helper()
+	var b [32]byte
+	b[31] = n
+	return secp256k1.PrivKeyFromBytes(b[:])
+}
+
+// personalSign signs msg the way wallets do for personal_sign and returns r || s || v
+// with v = 27 + recovery id.
+func personalSign(key *secp256k1.PrivateKey, msg string) string {
+	digest := keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(msg), msg)))
+	compact := ecdsa.SignCompact(key, digest, false) // v || r || s
+	sig := append(append([]byte{}, compact[1:]...), compact[0])
+	return "0x" + hex.EncodeToString(sig)
+}
+
+const testSIWEMessage = `app.example.com wants you to sign in with your Ethereum account:
+0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf
+
+Sign in to Job Portal
+
+URI: https://app.example.com
+Version: 1
+Chain ID: 11155111
+Nonce: 32891756abc
+Issued At: 2025-01-01T00:00:00Z
+Expiration Time: 2025-01-01T00:10:00Z
+Resources:
+- https://app.example.com/terms
+- https://app.example.com/privacy`
+
+func TestParseSIWEMessage(t *testing.T) {
+	m, err := ParseSIWEMessage(testSIWEMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issued := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	switch {
+	case m.Domain != "app.example.com":
+		t.Errorf("Domain = %q", m.Domain)
+	case m.Address != testKeyAddress:
+		t.Errorf("Address = %q", m.Address)
+	case m.Statement != "Sign in to Job Portal":
+		t.Errorf("Statement = %q", m.Statement)
+	case m.URI != "https://app.example.com" || m.Version != "1" || m.ChainID != 11155111 || m.Nonce != "32891756abc":
+		t.Errorf("URI/Version/ChainID/Nonce = %q %q %d %q", m.URI, m.Version, m.ChainID, m.Nonce)
+	case !m.IssuedAt.Equal(issued):
+		t.Errorf("IssuedAt = %v", m.IssuedAt)
+	case m.ExpirationTime == nil || !m.ExpirationTime.Equal(issued.Add(10*time.Minute)):
+		t.Errorf("ExpirationTime = %v", m.ExpirationTime)
+	case m.NotBefore != nil:
+		t.Errorf("NotBefore = %v, want nil", m.NotBefore)
+	case len(m.Resources) != 2 || m.Resources[1] != "https://app.example.com/privacy":
+		t.Errorf("Resources = %v", m.Resources)
+	}
+}
+
+func TestParseSIWEMessageInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace [2]string
+	}{
+		{"missing header", [2]string{" wants you to sign in with your Ethereum account:", ""}},
+		{"lowercase address", [2]string{testKeyAddress, strings.ToLower(testKeyAddress)}},
+		{"wrong version", [2]string{"Version: 1", "Version: 2"}},
+		{"bad chain id", [2]string{"Chain ID: 11155111", "Chain ID: sepolia"}},
+		{"short nonce", [2]string{"Nonce: 32891756abc", "Nonce: 1234"}},
+		{"bad issued at", [2]string{"Issued At: 2025-01-01T00:00:00Z", "Issued At: yesterday"}},
+		{"unknown field", [2]string{"Version: 1", "Version: 1\nColor: blue"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := strings.Replace(testSIWEMessage, tt.replace[0], tt.replace[1], 1)
+			if _, err := ParseSIWEMessage(msg); !errors.Is(err, ErrInvalidSIWEMessage) {
+				t.Errorf("err = %v, want ErrInvalidSIWEMessage", err)
+			}
+		})
+	}
+}
+
+func TestRecoverPersonalSignAddress(t *testing.T) {
+	sig := personalSign(testKey(t, 1), testSIWEMessage)
+	got, err := RecoverPersonalSignAddress(testSIWEMessage, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != testKeyAddress {
+		t.Errorf("signer = %s, want %s", got, testKeyAddress)
+	}
+
+	// Some wallets send v as the raw recovery id (0 or 1)
+	raw, _ := hex.DecodeString(sig[2:])
+	raw[64] -= 27
+	got, err = RecoverPersonalSignAddress(testSIWEMessage, "0x"+hex.EncodeToString(raw))
+	if err != nil || got != testKeyAddress {
+		t.Errorf("v in {0, 1}: signer = %s, err = %v", got, err)
+	}
+}
+
+func TestRecoverPersonalSignAddressBadSignature(t *testing.T) {
+	sig := personalSign(testKey(t, 1), testSIWEMessage)
+
+	// Signed by a different key, or over a different message: recovers someone else
+	if got, err := RecoverPersonalSignAddress(testSIWEMessage, personalSign(testKey(t, 2), testSIWEMessage)); err == nil && got == testKeyAddress {
+		t.Errorf("other key: recovered %s", got)
+	}
+	if got, err := RecoverPersonalSignAddress(testSIWEMessage+" ", sig); err == nil && got == testKeyAddress {
+		t.Errorf("other message: recovered %s", got)
+	}
+
+	raw, _ := hex.DecodeString(sig[2:])
+	badV := append([]byte{}, raw...)
+	badV[64] = 29
+	for name, s := range map[string]string{
+		"not hex":   "0xzz" + sig[4:],
+		"too short": sig[:len(sig)-2],
+		"too long":  sig + "00",
+		"bad v":     "0x" + hex.EncodeToString(badV),
+		"zero r":    "0x" + strings.Repeat("0", 64) + sig[66:],
+	} {
+		if _, err := RecoverPersonalSignAddress(testSIWEMessage, s); err != ErrInvalidSignature {
+			t.Errorf("%s: err = %v, want ErrInvalidSignature", name, err)
+		}
+	}
+}
+
+func TestToChecksumAddress(t *testing.T) {
+	// Test vectors from EIP-55
+	for _, want := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		if got := ToChecksumAddress(strings.ToLower(want)); got != want {
+			t.Errorf("ToChecksumAddress = %s, want %s", got, want)
+		}
+		if !IsChecksumAddress(want) {
+			t.Errorf("IsChecksumAddress(%s) = false", want)
+		}
+		if IsChecksumAddress(strings.ToLower(want)) {
+			t.Errorf("IsChecksumAddress(%s) = true for the lowercase form", strings.ToLower(want))
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters (RFC 6238 defaults, understood by every authenticator app).
+const (
+	TOTPDigits = 6
+	TOTPPeriod = 30 * time.Second
+	// totpSkew is how many periods before/after the current one are accepted for clock drift.
+	totpSkew = 1
+)
+
+// ErrInvalidTOTPSecret is returned when a stored secret isn't valid base32.
+var ErrInvalidTOTPSecret = errors.New("invalid TOTP secret")
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret returns a random 160-bit secret, base32 encoded without padding
+// as authenticator apps expect.
+//
+// Usage: secret, err := GenerateTOTPSecret()
+func GenerateTOTPSecret() (string, error) {
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(buf), nil
+}
+
+// TOTPURI builds the otpauth:// URI that authenticator apps import (usually shown as a QR code).
+//
+// Parameters:
+// - issuer: Service name shown in the app (TOTP_ISSUER)
+// - account: Account label, typically the user's email
+// - secret: Base32 secret from GenerateTOTPSecret
+//
+// Returns: otpauth://totp/Issuer:account?secret=...&issuer=...&algorithm=SHA1&digits=6&period=30
+func TOTPURI(issuer, account, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(TOTPDigits))
+	q.Set("period", fmt.Sprint(int(TOTPPeriod.Seconds())))
+	label := url.PathEscape(issuer + ":" + account)
+	// Authenticator apps expect %20 rather than + for spaces
+	return "otpauth://totp/" + label + "?" + strings.ReplaceAll(q.Encode(), "+", "%20")
+}
+
+// TOTPCode computes the code for the period containing t (RFC 6238 with HMAC-SHA1).
+func TOTPCode(secret string, t time.Time) (string, error) {
+	return totpCodeAt(secret, totpStep(t))
+}
+
+// ValidateTOTP checks a code against the periods around t.
+//
+// Returns:
+// - step: The matching period number; store it and reject codes with a step at or below it to stop replays
+// - ok: Whether the code matched
+// - ErrInvalidTOTPSecret if the secret can't be decoded
+//
+// Usage: step, ok, err := ValidateTOTP(secret, code, time.Now())
+func ValidateTOTP(secret, code string, t time.Time) (int64, bool, error) {
+	if len(code) != TOTPDigits {
+		return 0, false, nil
+	}
+	current := totpStep(t)
+	for i := -totpSkew; i <= totpSkew; i++ {
+		step := current + int64(i)
+		want, err := totpCodeAt(secret, step)
+		if err != nil {
+			return 0, false, err
+		}
+		if hmac.Equal([]byte(want), []byte(code)) {
+			return step, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func totpStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod.Seconds())
+}
+
+// totpCodeAt implements HOTP (RFC 4226) for the given counter.
+func totpCodeAt(secret string, counter int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", ErrInvalidTOTPSecret
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod), nil
+}
@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of RFC 6238 Appendix B ("12345678901234567890") in base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// rfc6238Vectors are the SHA-1 test vectors of RFC 6238 Appendix B. The RFC lists 8-digit
+// codes; TOTPDigits is 6, so code holds their last six digits.
+var rfc6238Vectors = []struct {
+	unix int64
+	step int64
+	code string
+}{
+	{59, 0x1, "287082"},
+	{1111111109, 0x23523EC, "081804"},
+	{1111111111, 0x23523ED, "050471"},
+	{1234567890, 0x273EF07, "005924"},
+	{2000000000, 0x3F940AA, "279037"},
+	{20000000000, 0x27BC86AA, "353130"},
+}
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	for _, v := range rfc6238Vectors {
+		at := time.Unix(v.unix, 0).UTC()
+		if got := totpStep(at); got != v.step {
+			t.Errorf("totpStep(%d) = %#x, want %#x", v.unix, got, v.step)
+		}
+		got, err := TOTPCode(rfc6238Secret, at)
+		if err != nil {
+			t.Fatalf("TOTPCode(%d): %v", v.unix, err)
+		}
+		if got != v.code {
+			t.Errorf("TOTPCode(%d) = %s, want %s", v.unix, got, v.code)
+		}
+	}
+}
+
+func TestTOTPCodeSecretFormats(t *testing.T) {
+	at := time.Unix(59, 0)
+	for _, secret := range []string{
+		strings.ToLower(rfc6238Secret),
+		rfc6238Secret + "========",
+	} {
+		got, err := TOTPCode(secret, at)
+		if err != nil {
+			t.Fatalf("TOTPCode(%q): %v", secret, err)
+		}
+		if got != "287082" {
+			t.Errorf("TOTPCode(%q) = %s, want 287082", secret, got)
+		}
+	}
+}
+
+func TestValidateTOTPWindow(t *testing.T) {
+	// Code for step 0x23523ED (RFC 6238: t = 1111111111)
+	const code = "050471"
+	const step = 0x23523ED
+	period := int64(TOTPPeriod.Seconds())
+
+	tests := []struct {
+		name   string
+		offset int64 // periods between the validation time and the code's step
+		ok     bool
+	}{
+		{"current period", 0, true},
+		{"one period late", 1, true},
+		{"one period early", -1, true},
+		{"two periods late", 2, false},
+		{"two periods early", -2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := time.Unix((step+tt.offset)*period, 0)
+			got, ok, err := ValidateTOTP(rfc6238Secret, code, at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			// The matching step, not the current one, is returned for the replay check
+			if ok && got != step {
+				t.Errorf("step = %#x, want %#x", got, step)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPReplayStep(t *testing.T) {
+	// Callers store the returned step and only accept codes with a later one
+	// (users.totp_last_step). A code reused within the skew window must map to
+	// the same step, and the next period's code to a later one.
+	at := time.Unix(1111111111, 0)
+	code, err := TOTPCode(rfc6238Secret, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, ok, err := ValidateTOTP(rfc6238Secret, code, at)
+	if err != nil || !ok {
+		t.Fatalf("first use: ok = %v, err = %v", ok, err)
+	}
+	again, ok, err := ValidateTOTP(rfc6238Secret, code, at.Add(TOTPPeriod))
+	if err != nil || !ok {
+		t.Fatalf("replay: ok = %v, err = %v", ok, err)
+	}
+	if again > first {
+		t.Errorf("replayed code returned step %#x after %#x; it would be accepted twice", again, first)
+	}
+
+	next, err := TOTPCode(rfc6238Secret, at.Add(TOTPPeriod))
+	if err != nil {
+		t.Fatal(err)
+	}
+	step, ok, err := ValidateTOTP(rfc6238Secret, next, at.Add(TOTPPeriod))
+	if err != nil || !ok {
+		t.Fatalf("next code: ok = %v, err = %v", ok, err)
+	}
+	if step <= first {
+		t.Errorf("next period's code returned step %#x, want more than %#x", step, first)
+	}
+}
+
+func TestValidateTOTPRejects(t *testing.T) {
+	at := time.Unix(59, 0)
+	for _, code := range []string{"", "28708", "2870820", "287083", "abcdef"} {
+		if _, ok, err := ValidateTOTP(rfc6238Secret, code, at); ok || err != nil {
+			t.Errorf("ValidateTOTP(%q) = ok %v, err %v; want rejected without error", code, ok, err)
+		}
+	}
+
+	if _, _, err := ValidateTOTP("not base32!", "287082", at); err != ErrInvalidTOTPSecret {
+		t.Errorf("invalid secret: err = %v, want ErrInvalidTOTPSecret", err)
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 160 bits encode to 32 base32 characters without padding
+	if len(secret) != 32 || strings.Contains(secret, "=") {
+		t.Errorf("secret %q: want 32 unpadded base32 characters", secret)
+	}
+	if _, err := TOTPCode(secret, time.Now()); err != nil {
+		t.Errorf("generated secret doesn't decode: %v", err)
+	}
+}